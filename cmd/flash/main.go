@@ -5,10 +5,26 @@ import (
 	"os"
 
 	"github.com/jmcveigh55/flash/pkg/core/adding"
+	"github.com/jmcveigh55/flash/pkg/core/attaching"
+	"github.com/jmcveigh55/flash/pkg/core/burying"
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
 	"github.com/jmcveigh55/flash/pkg/core/deleting"
+	"github.com/jmcveigh55/flash/pkg/core/exporting"
 	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/logging"
+	"github.com/jmcveigh55/flash/pkg/core/moving"
+	"github.com/jmcveigh55/flash/pkg/core/optimizing"
+	"github.com/jmcveigh55/flash/pkg/core/quizzing"
+	"github.com/jmcveigh55/flash/pkg/core/reporting"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/core/revising"
+	"github.com/jmcveigh55/flash/pkg/core/simulating"
+	"github.com/jmcveigh55/flash/pkg/core/studying"
+	"github.com/jmcveigh55/flash/pkg/core/suspending"
+	"github.com/jmcveigh55/flash/pkg/core/tagging"
 	"github.com/jmcveigh55/flash/pkg/core/updating"
 	"github.com/jmcveigh55/flash/pkg/interface/cli"
+	"github.com/jmcveigh55/flash/pkg/storage"
 	"github.com/jmcveigh55/flash/pkg/storage/json"
 )
 
@@ -21,10 +37,58 @@ func main() {
 	d := deleting.New(r)
 	g := getting.New(r)
 	u := updating.New(r)
+	clock := storage.NewClock()
+	rv := reviewing.New(r, clock)
+	c := configuring.New(r, validateConfig)
+	s := studying.New(g, rv, r, clock)
+	q := quizzing.New(g, clock)
+	l := logging.New(r)
+	st := reporting.New(g, l, c, clock)
+	sp := suspending.New(r)
+	b := burying.New(r, clock)
+	sm := simulating.New(g, r, clock)
+	o := optimizing.New(l, c)
+	m := moving.New(g, r)
+	t := tagging.New(g, r)
+	at := attaching.New(g, r)
+	rs := revising.New(g, u)
+	e := exporting.New(g, r)
 
-	app := cli.New(a, d, g, u)
+	app := cli.New(cli.Services{
+		Adding:      a,
+		Deleting:    d,
+		Getting:     g,
+		Updating:    u,
+		Reviewing:   rv,
+		Configuring: c,
+		Studying:    s,
+		Quizzing:    q,
+		Logging:     l,
+		Reporting:   st,
+		Suspending:  sp,
+		Burying:     b,
+		Simulating:  sm,
+		Optimizing:  o,
+		Moving:      m,
+		Tagging:     t,
+		Attaching:   at,
+		Revising:    rs,
+		Exporting:   e,
+	})
 
 	if err := app.Run(os.Args); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// validateConfig has the reviewing service check the settings of a config
+// that reviews use.
+func validateConfig(c configuring.Config) error {
+	return reviewing.Validate(reviewing.Settings{
+		Scheduler:   c.Scheduler,
+		Weights:     c.Weights,
+		Retention:   c.Retention,
+		Boxes:       c.Boxes,
+		LeechAction: c.LeechAction,
+	})
+}
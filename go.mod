@@ -3,13 +3,22 @@ module github.com/jmcveigh55/flash
 go 1.19
 
 require (
+	github.com/gdamore/tcell/v2 v2.5.3
+	github.com/mattn/go-runewidth v0.0.13
 	github.com/nanobox-io/golang-scribble v0.0.0-20190309225732-aa3e7c118975
+	github.com/rivo/tview v0.0.0-20221029100920-c4a7e501810d
+	github.com/russross/blackfriday/v2 v2.1.0
 	github.com/urfave/cli/v2 v2.20.2
+	golang.org/x/term v0.5.0
+	golang.org/x/text v0.3.7
 )
 
 require (
 	github.com/cpuguy83/go-md2man/v2 v2.0.2 // indirect
+	github.com/gdamore/encoding v1.0.0 // indirect
 	github.com/jcelliott/lumber v0.0.0-20160324203708-dd349441af25 // indirect
-	github.com/russross/blackfriday/v2 v2.1.0 // indirect
+	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
+	github.com/rivo/uniseg v0.4.2 // indirect
 	github.com/xrash/smetrics v0.0.0-20201216005158-039620a65673 // indirect
+	golang.org/x/sys v0.5.0 // indirect
 )
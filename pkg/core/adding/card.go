@@ -1,6 +1,10 @@
 package adding
 
 type Card struct {
-	Title string
-	Desc  string
+	Title      string
+	Desc       string
+	Answers    []string
+	Reversible bool
+	Cloze      bool
+	Tags       []string
 }
@@ -0,0 +1,25 @@
+package adding
+
+// NoteType is a named list of fields and the templates that turn a note's
+// fields into cards.
+type NoteType struct {
+	Name      string
+	Fields    []string
+	Templates []Template
+}
+
+// Template is a card of a note type. Each template of a note is reviewed as
+// its own face, named after the template.
+type Template struct {
+	Name  string
+	Front string
+	Back  string
+}
+
+// Note is a card whose sides are filled in from its type's templates.
+type Note struct {
+	Title  string
+	Type   string
+	Fields map[string]string
+	Tags   []string
+}
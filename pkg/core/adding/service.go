@@ -1,15 +1,40 @@
 package adding
 
-import "errors"
+import (
+	"errors"
 
-var ErrCardEmptyTitle error = errors.New("card has an empty title")
+	"github.com/jmcveigh55/flash/pkg/core/cloze"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/note"
+)
+
+var (
+	ErrCardEmptyTitle  error = errors.New("card has an empty title")
+	ErrClozeNotFound   error = errors.New("cloze card has no {{cN::text}} deletions")
+	ErrClozeReversible error = errors.New("cloze card cannot be reversible")
+	ErrInvalidTag      error = errors.New("tag may only contain letters, digits and _-./:")
+
+	ErrNoteTypeEmptyName error = errors.New("note type has an empty name")
+	ErrFieldsNotFound    error = errors.New("note type has no fields")
+	ErrInvalidField      error = errors.New("field name may not be empty, FrontSide or contain {}:")
+	ErrDuplicateField    error = errors.New("note type has a field twice")
+	ErrTemplatesNotFound error = errors.New("note type has no templates")
+	ErrInvalidTemplate   error = errors.New("template needs a name and a front")
+	ErrDuplicateTemplate error = errors.New("note type has a template twice")
+	ErrUnknownField      error = errors.New("field is not one of the note type's")
+)
 
 type Service interface {
 	AddCard(string, Card) error
+	AddNoteType(NoteType) error
+	AddNote(string, Note) error
 }
 
 type Repository interface {
 	AddCard(string, Card) error
+	AddNoteType(NoteType) error
+	GetNoteType(string) (NoteType, error)
+	AddNote(string, Note) error
 }
 
 type service struct {
@@ -24,5 +49,95 @@ func (s *service) AddCard(g string, c Card) error {
 	if c.Title == "" {
 		return ErrCardEmptyTitle
 	}
+	if c.Cloze {
+		if c.Reversible {
+			return ErrClozeReversible
+		}
+		if len(cloze.Faces(c.Desc)) == 0 {
+			return ErrClozeNotFound
+		}
+	}
+	if err := validateTags(c.Tags); err != nil {
+		return err
+	}
 	return s.r.AddCard(g, c)
 }
+
+// AddNoteType checks that every field and template is named once and that
+// the templates only use the type's fields.
+func (s *service) AddNoteType(t NoteType) error {
+	if t.Name == "" {
+		return ErrNoteTypeEmptyName
+	}
+	if len(t.Fields) == 0 {
+		return ErrFieldsNotFound
+	}
+	if len(t.Templates) == 0 {
+		return ErrTemplatesNotFound
+	}
+
+	fields := map[string]bool{}
+	for _, f := range t.Fields {
+		if !note.ValidField(f) {
+			return ErrInvalidField
+		}
+		if fields[f] {
+			return ErrDuplicateField
+		}
+		fields[f] = true
+	}
+
+	templates := map[string]bool{}
+	for _, tmpl := range t.Templates {
+		if tmpl.Name == "" || tmpl.Front == "" {
+			return ErrInvalidTemplate
+		}
+		if templates[tmpl.Name] {
+			return ErrDuplicateTemplate
+		}
+		templates[tmpl.Name] = true
+
+		used := append(note.Fields(tmpl.Front), note.Fields(tmpl.Back)...)
+		for _, f := range used {
+			if !fields[f] && f != note.FrontSide {
+				return ErrUnknownField
+			}
+		}
+	}
+	return s.r.AddNoteType(t)
+}
+
+// AddNote adds a note of an existing type. Fields the note leaves out are
+// empty.
+func (s *service) AddNote(g string, n Note) error {
+	if n.Title == "" {
+		return ErrCardEmptyTitle
+	}
+	t, err := s.r.GetNoteType(n.Type)
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]bool{}
+	for _, f := range t.Fields {
+		fields[f] = true
+	}
+	for f := range n.Fields {
+		if !fields[f] {
+			return ErrUnknownField
+		}
+	}
+	if err := validateTags(n.Tags); err != nil {
+		return err
+	}
+	return s.r.AddNote(g, n)
+}
+
+func validateTags(tags []string) error {
+	for _, t := range tags {
+		if !getting.ValidTag(t) {
+			return ErrInvalidTag
+		}
+	}
+	return nil
+}
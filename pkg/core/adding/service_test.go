@@ -1,12 +1,39 @@
 package adding
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
 
+var errNoteTypeNotFound error = errors.New("note type not found")
+
 type repositoryStub struct {
 	cards []Card
+	types []NoteType
+	notes []Note
+}
+
+func (r *repositoryStub) AddNoteType(t NoteType) error {
+	r.types = append(r.types, t)
+	return nil
+}
+
+func (r *repositoryStub) GetNoteType(name string) (NoteType, error) {
+	for _, t := range r.types {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return NoteType{}, errNoteTypeNotFound
+}
+
+func (r *repositoryStub) AddNote(g string, n Note) error {
+	if g != "" {
+		n.Title = g + "." + n.Title
+	}
+	r.notes = append(r.notes, n)
+	return nil
 }
 
 func (r *repositoryStub) AddCard(g string, c Card) error {
@@ -53,6 +80,41 @@ func TestAddCard(t *testing.T) {
 			want:    []Card{{Title: "Subject", Desc: ""}},
 			wantErr: nil,
 		},
+		{
+			name:    "Cloze",
+			group:   "Group",
+			card:    Card{Title: "Subject", Desc: "{{c1::Value}}", Cloze: true},
+			want:    []Card{{Title: "Group.Subject", Desc: "{{c1::Value}}", Cloze: true}},
+			wantErr: nil,
+		},
+		{
+			name:    "Cloze Not Found",
+			group:   "Group",
+			card:    Card{Title: "Subject", Desc: "Value", Cloze: true},
+			want:    nil,
+			wantErr: ErrClozeNotFound,
+		},
+		{
+			name:    "Cloze Reversible",
+			group:   "Group",
+			card:    Card{Title: "Subject", Desc: "{{c1::Value}}", Cloze: true, Reversible: true},
+			want:    nil,
+			wantErr: ErrClozeReversible,
+		},
+		{
+			name:    "Tags",
+			group:   "Group",
+			card:    Card{Title: "Subject", Desc: "Value", Tags: []string{"go", "lang/go"}},
+			want:    []Card{{Title: "Group.Subject", Desc: "Value", Tags: []string{"go", "lang/go"}}},
+			wantErr: nil,
+		},
+		{
+			name:    "Invalid Tag",
+			group:   "Group",
+			card:    Card{Title: "Subject", Desc: "Value", Tags: []string{"go rust"}},
+			want:    nil,
+			wantErr: ErrInvalidTag,
+		},
 		{
 			name:    "Empty Title",
 			group:   "Group",
@@ -78,3 +140,131 @@ func TestAddCard(t *testing.T) {
 		})
 	}
 }
+
+var vocab = NoteType{
+	Name:   "Vocab",
+	Fields: []string{"Word", "Reading", "Meaning"},
+	Templates: []Template{
+		{Name: "Recognise", Front: "{{Word}}", Back: "{{Reading}}: {{Meaning}}"},
+		{Name: "Recall", Front: "{{Meaning}}", Back: "{{FrontSide}} = {{Word}}"},
+	},
+}
+
+func TestAddNoteType(t *testing.T) {
+	tests := []struct {
+		name     string
+		noteType NoteType
+		wantErr  error
+	}{
+		{"Normal", vocab, nil},
+		{"Empty Name", NoteType{Fields: vocab.Fields, Templates: vocab.Templates}, ErrNoteTypeEmptyName},
+		{"No Fields", NoteType{Name: "Vocab", Templates: vocab.Templates}, ErrFieldsNotFound},
+		{"No Templates", NoteType{Name: "Vocab", Fields: vocab.Fields}, ErrTemplatesNotFound},
+		{
+			name:     "Invalid Field",
+			noteType: NoteType{Name: "Vocab", Fields: []string{"Front:Side"}, Templates: vocab.Templates},
+			wantErr:  ErrInvalidField,
+		},
+		{
+			name:     "Duplicate Field",
+			noteType: NoteType{Name: "Vocab", Fields: []string{"Word", "Word"}, Templates: vocab.Templates},
+			wantErr:  ErrDuplicateField,
+		},
+		{
+			name: "Invalid Template",
+			noteType: NoteType{Name: "Vocab", Fields: vocab.Fields, Templates: []Template{
+				{Name: "Recognise", Back: "{{Word}}"},
+			}},
+			wantErr: ErrInvalidTemplate,
+		},
+		{
+			name: "Duplicate Template",
+			noteType: NoteType{Name: "Vocab", Fields: vocab.Fields, Templates: []Template{
+				vocab.Templates[0], vocab.Templates[0],
+			}},
+			wantErr: ErrDuplicateTemplate,
+		},
+		{
+			name: "Unknown Field",
+			noteType: NoteType{Name: "Vocab", Fields: vocab.Fields, Templates: []Template{
+				{Name: "Example", Front: "{{Example}}", Back: "{{Word}}"},
+			}},
+			wantErr: ErrUnknownField,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &repositoryStub{}
+			err := New(repo).AddNoteType(tt.noteType)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && !reflect.DeepEqual([]NoteType{tt.noteType}, repo.types) {
+				t.Errorf("Incorrect repo.types. Want %v, got %v", []NoteType{tt.noteType}, repo.types)
+			}
+		})
+	}
+}
+
+func TestAddNote(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		note    Note
+		want    []Note
+		wantErr error
+	}{
+		{
+			name:    "Normal",
+			group:   "Japanese",
+			note:    Note{Title: "Cat", Type: "Vocab", Fields: map[string]string{"Word": "猫", "Meaning": "cat"}},
+			want:    []Note{{Title: "Japanese.Cat", Type: "Vocab", Fields: map[string]string{"Word": "猫", "Meaning": "cat"}}},
+			wantErr: nil,
+		},
+		{
+			name:    "Unknown Field",
+			group:   "Japanese",
+			note:    Note{Title: "Cat", Type: "Vocab", Fields: map[string]string{"Example": "猫がいる"}},
+			want:    nil,
+			wantErr: ErrUnknownField,
+		},
+		{
+			name:    "Note Type Not Found",
+			group:   "Japanese",
+			note:    Note{Title: "Cat", Type: "Grammar"},
+			want:    nil,
+			wantErr: errNoteTypeNotFound,
+		},
+		{
+			name:    "Invalid Tag",
+			group:   "Japanese",
+			note:    Note{Title: "Cat", Type: "Vocab", Tags: []string{"n5 animals"}},
+			want:    nil,
+			wantErr: ErrInvalidTag,
+		},
+		{
+			name:    "Empty Title",
+			group:   "Japanese",
+			note:    Note{Type: "Vocab"},
+			want:    nil,
+			wantErr: ErrCardEmptyTitle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &repositoryStub{types: []NoteType{vocab}}
+			err := New(repo).AddNote(tt.group, tt.note)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, repo.notes) {
+				t.Errorf("Incorrect repo.notes. Want %v, got %v", tt.want, repo.notes)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package attaching
+
+type Card struct {
+	ID    string
+	Title string
+}
+
+// File is a file to attach, named without its directory.
+type File struct {
+	Name string
+	Data []byte
+}
+
+// Media is a file attached to a card, under its original name and the name
+// it is stored under.
+type Media struct {
+	Name string
+	File string
+}
@@ -0,0 +1,115 @@
+package attaching
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+)
+
+var (
+	ErrCardEmptyTitle error = errors.New("card has an empty title")
+	ErrFileEmptyName  error = errors.New("file has an empty name")
+	ErrInvalidName    error = errors.New("file name may not contain a path")
+)
+
+// Service attaches files, such as diagrams and screenshots, to cards. Each
+// file is stored once as media named by the hash of its content, however
+// many cards it is attached to.
+type Service interface {
+	Attach(string, Card, File) (Media, error)
+	RemoveUnusedMedia() ([]string, error)
+}
+
+type Repository interface {
+	AddMedia(string, []byte) error
+	AttachMedia(string, Card, Media) error
+	GetMedia() ([]string, error)
+	DeleteMedia(string) error
+}
+
+type service struct {
+	g getting.Service
+	r Repository
+}
+
+func New(g getting.Service, r Repository) *service {
+	return &service{g, r}
+}
+
+// Attach stores the file as media, if it is not already, and attaches it to
+// the card.
+func (s *service) Attach(g string, c Card, f File) (Media, error) {
+	if c.ID == "" && c.Title == "" {
+		return Media{}, ErrCardEmptyTitle
+	}
+	if f.Name == "" {
+		return Media{}, ErrFileEmptyName
+	}
+	if strings.ContainsAny(f.Name, `/\`) {
+		return Media{}, ErrInvalidName
+	}
+
+	m := Media{Name: f.Name, File: MediaFile(f)}
+	if err := s.r.AddMedia(m.File, f.Data); err != nil {
+		return Media{}, err
+	}
+	if err := s.r.AttachMedia(g, c, m); err != nil {
+		return Media{}, err
+	}
+	return m, nil
+}
+
+// RemoveUnusedMedia deletes the media no card is attached to, returning
+// their files. Once every card is deleted, all media is unused.
+func (s *service) RemoveUnusedMedia() ([]string, error) {
+	files, err := s.r.GetMedia()
+	if err != nil || len(files) == 0 {
+		return nil, err
+	}
+	cards, err := s.g.GetAllCards("", getting.All)
+	if err != nil && !errors.Is(err, getting.ErrGroupNotFound) {
+		return nil, err
+	}
+
+	used := map[string]bool{}
+	for _, c := range cards {
+		for _, m := range c.Media {
+			used[m.File] = true
+		}
+	}
+
+	var removed []string
+	for _, f := range files {
+		if used[f] {
+			continue
+		}
+		if err := s.r.DeleteMedia(f); err != nil {
+			return removed, err
+		}
+		removed = append(removed, f)
+	}
+	return removed, nil
+}
+
+// MediaFile returns the name a file is stored under: the SHA-256 hash of its
+// content, followed by its extension so it can still be opened by type.
+func MediaFile(f File) string {
+	sum := sha256.Sum256(f.Data)
+	return hex.EncodeToString(sum[:]) + extension(f.Name)
+}
+
+// extension returns the lower case extension of a file name, or nothing if
+// it is not made of letters and digits.
+func extension(name string) string {
+	ext := strings.ToLower(path.Ext(name))
+	for _, r := range strings.TrimPrefix(ext, ".") {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return ""
+		}
+	}
+	return ext
+}
@@ -0,0 +1,223 @@
+package attaching
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+)
+
+var errCardNotFound error = errors.New("card not found")
+
+// storeStub is both the getting service and the repository.
+type storeStub struct {
+	cards map[string][]getting.Media
+	media map[string][]byte
+}
+
+func newStoreStubWithCards() *storeStub {
+	return &storeStub{
+		cards: map[string][]getting.Media{
+			"Subject1":       nil,
+			"Group.Subject1": {{Name: "a.png", File: "used.png"}},
+		},
+		media: map[string][]byte{
+			"used.png":   []byte("a"),
+			"unused.png": []byte("b"),
+		},
+	}
+}
+
+func (s *storeStub) GetCards(string, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (s *storeStub) GetAllCards(string, getting.Filter) ([]getting.Card, error) {
+	var cards []getting.Card
+	for title, media := range s.cards {
+		cards = append(cards, getting.Card{Title: title, Media: media})
+	}
+	if len(cards) == 0 {
+		return nil, getting.ErrGroupNotFound
+	}
+	return cards, nil
+}
+
+func (s *storeStub) GetCardsInBox(string, int, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (s *storeStub) GetLeeches(string) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (s *storeStub) AddMedia(file string, data []byte) error {
+	s.media[file] = data
+	return nil
+}
+
+func (s *storeStub) AttachMedia(g string, c Card, m Media) error {
+	title := g + "." + c.Title
+	if _, ok := s.cards[title]; !ok {
+		return errCardNotFound
+	}
+	s.cards[title] = append(s.cards[title], getting.Media{Name: m.Name, File: m.File})
+	return nil
+}
+
+func (s *storeStub) GetMedia() ([]string, error) {
+	var files []string
+	for f := range s.media {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (s *storeStub) DeleteMedia(file string) error {
+	delete(s.media, file)
+	return nil
+}
+
+func TestAttach(t *testing.T) {
+	png := File{Name: "Diagram.PNG", Data: []byte("diagram")}
+	pngFile := MediaFile(png)
+
+	tests := []struct {
+		name  string
+		group string
+		card  Card
+		file  File
+		want  Media
+		err   error
+	}{
+		{
+			name:  "attached",
+			group: "Group",
+			card:  Card{Title: "Subject1"},
+			file:  png,
+			want:  Media{Name: "Diagram.PNG", File: pngFile},
+		},
+		{
+			name:  "empty title",
+			group: "Group",
+			file:  png,
+			err:   ErrCardEmptyTitle,
+		},
+		{
+			name:  "empty name",
+			group: "Group",
+			card:  Card{Title: "Subject1"},
+			file:  File{Data: []byte("diagram")},
+			err:   ErrFileEmptyName,
+		},
+		{
+			name:  "path",
+			group: "Group",
+			card:  Card{Title: "Subject1"},
+			file:  File{Name: "../a.png", Data: []byte("diagram")},
+			err:   ErrInvalidName,
+		},
+		{
+			name:  "card not found",
+			group: "Group",
+			card:  Card{Title: "Subject3"},
+			file:  png,
+			err:   errCardNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := newStoreStubWithCards()
+			s := New(store, store)
+
+			got, err := s.Attach(test.group, test.card, test.file)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Incorrect error. Want %v, got %v", test.err, err)
+			}
+			if got != test.want {
+				t.Errorf("Incorrect media. Want %v, got %v", test.want, got)
+			}
+			if test.err != nil {
+				return
+			}
+			if data := store.media[got.File]; string(data) != string(test.file.Data) {
+				t.Errorf("Incorrect media data. Want %q, got %q", test.file.Data, data)
+			}
+			media := store.cards[test.group+"."+test.card.Title]
+			if len(media) == 0 || media[len(media)-1].File != got.File {
+				t.Errorf("Incorrect card media. Want %v attached, got %v", got, media)
+			}
+		})
+	}
+}
+
+func TestRemoveUnusedMedia(t *testing.T) {
+	store := newStoreStubWithCards()
+	s := New(store, store)
+
+	removed, err := s.RemoveUnusedMedia()
+	if err != nil {
+		t.Fatalf("Incorrect error. Want %v, got %v", nil, err)
+	}
+	if want := []string{"unused.png"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("Incorrect removed media. Want %v, got %v", want, removed)
+	}
+	files, _ := store.GetMedia()
+	if want := []string{"used.png"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("Incorrect media left. Want %v, got %v", want, files)
+	}
+}
+
+func TestRemoveUnusedMediaNoCards(t *testing.T) {
+	store := newStoreStubWithCards()
+	store.cards = map[string][]getting.Media{}
+	s := New(store, store)
+
+	removed, err := s.RemoveUnusedMedia()
+	if err != nil {
+		t.Fatalf("Incorrect error. Want %v, got %v", nil, err)
+	}
+	if want := []string{"unused.png", "used.png"}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("Incorrect removed media. Want %v, got %v", want, removed)
+	}
+	files, _ := store.GetMedia()
+	if len(files) != 0 {
+		t.Errorf("Incorrect media left. Want none, got %v", files)
+	}
+}
+
+func TestMediaFile(t *testing.T) {
+	tests := []struct {
+		name string
+		file File
+		want string
+	}{
+		{
+			name: "extension",
+			file: File{Name: "a.PNG", Data: []byte("a")},
+			want: "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb.png",
+		},
+		{
+			name: "no extension",
+			file: File{Name: "a", Data: []byte("a")},
+			want: "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb",
+		},
+		{
+			name: "odd extension",
+			file: File{Name: "a.p g", Data: []byte("a")},
+			want: "ca978112ca1bbdcafac231b39a23dc4da786eff8147c4e72b9807785afee48bb",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := MediaFile(test.file); got != test.want {
+				t.Errorf("Incorrect media file. Want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package burying
+
+type Card struct {
+	Title string
+}
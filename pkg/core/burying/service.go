@@ -0,0 +1,55 @@
+package burying
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/storage"
+)
+
+var (
+	ErrCardEmptyTitle error = errors.New("card has an empty title")
+	ErrGroupEmptyName error = errors.New("group has an empty name")
+)
+
+// Service buries cards, leaving them out of study sessions for the rest of
+// the day.
+type Service interface {
+	BuryCard(string, Card) error
+	BuryGroup(string) error
+}
+
+type Repository interface {
+	SetCardBuried(string, Card, time.Time) error
+	SetGroupBuried(string, time.Time) error
+}
+
+type service struct {
+	r     Repository
+	clock storage.Clock
+}
+
+func New(r Repository, c storage.Clock) *service {
+	return &service{r, c}
+}
+
+func (s *service) BuryCard(g string, c Card) error {
+	if c.Title == "" {
+		return ErrCardEmptyTitle
+	}
+	return s.r.SetCardBuried(g, c, s.tomorrow())
+}
+
+func (s *service) BuryGroup(g string) error {
+	if g == "" {
+		return ErrGroupEmptyName
+	}
+	return s.r.SetGroupBuried(g, s.tomorrow())
+}
+
+// tomorrow returns the start of the next day, when buried cards return.
+func (s *service) tomorrow() time.Time {
+	now := s.clock.Now()
+	y, m, d := now.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, now.Location())
+}
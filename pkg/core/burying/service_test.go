@@ -0,0 +1,182 @@
+package burying
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	errCardNotFound  error = errors.New("card not found")
+	errGroupNotFound error = errors.New("group not found")
+)
+
+var (
+	now      = time.Date(2022, time.November, 1, 12, 0, 0, 0, time.UTC)
+	tomorrow = time.Date(2022, time.November, 2, 0, 0, 0, 0, time.UTC)
+)
+
+type clockStub struct {
+	t time.Time
+}
+
+func (c *clockStub) Now() time.Time {
+	return c.t
+}
+
+type repositoryStub struct {
+	buried map[string]time.Time
+}
+
+func newRepositoryStubWithCards() *repositoryStub {
+	return &repositoryStub{
+		buried: map[string]time.Time{
+			"Subject1":                {},
+			"Group.Subject1":          {},
+			"Group.SubGroup.Subject1": {},
+		},
+	}
+}
+
+func (r *repositoryStub) SetCardBuried(g string, c Card, t time.Time) error {
+	if g != "" {
+		c.Title = g + "." + c.Title
+	}
+	if _, ok := r.buried[c.Title]; !ok {
+		return errCardNotFound
+	}
+	r.buried[c.Title] = t
+	return nil
+}
+
+func (r *repositoryStub) SetGroupBuried(g string, t time.Time) error {
+	found := false
+	for title := range r.buried {
+		if g == "" || strings.HasPrefix(title, g+".") {
+			r.buried[title] = t
+			found = true
+		}
+	}
+	if !found {
+		return errGroupNotFound
+	}
+	return nil
+}
+
+func TestBuryCard(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		card    Card
+		want    map[string]time.Time
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			card:  Card{Title: "Subject1"},
+			want: map[string]time.Time{
+				"Subject1":                {},
+				"Group.Subject1":          tomorrow,
+				"Group.SubGroup.Subject1": {},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Card Not Found",
+			group: "Group",
+			card:  Card{Title: "Subject2"},
+			want: map[string]time.Time{
+				"Subject1":                {},
+				"Group.Subject1":          {},
+				"Group.SubGroup.Subject1": {},
+			},
+			wantErr: errCardNotFound,
+		},
+		{
+			name:  "Card Empty Title",
+			group: "Group",
+			card:  Card{Title: ""},
+			want: map[string]time.Time{
+				"Subject1":                {},
+				"Group.Subject1":          {},
+				"Group.SubGroup.Subject1": {},
+			},
+			wantErr: ErrCardEmptyTitle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepositoryStubWithCards()
+			bs := New(repo, &clockStub{now})
+			err := bs.BuryCard(tt.group, tt.card)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, repo.buried) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, repo.buried)
+			}
+		})
+	}
+}
+
+func TestBuryGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		want    map[string]time.Time
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			want: map[string]time.Time{
+				"Subject1":                {},
+				"Group.Subject1":          tomorrow,
+				"Group.SubGroup.Subject1": tomorrow,
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Group Empty Name",
+			group: "",
+			want: map[string]time.Time{
+				"Subject1":                {},
+				"Group.Subject1":          {},
+				"Group.SubGroup.Subject1": {},
+			},
+			wantErr: ErrGroupEmptyName,
+		},
+		{
+			name:  "Group Not Found",
+			group: "NotFound",
+			want: map[string]time.Time{
+				"Subject1":                {},
+				"Group.Subject1":          {},
+				"Group.SubGroup.Subject1": {},
+			},
+			wantErr: errGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepositoryStubWithCards()
+			bs := New(repo, &clockStub{now})
+			err := bs.BuryGroup(tt.group)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, repo.buried) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, repo.buried)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Package cloze parses cloze deletions, written {{cN::text}} or
+// {{cN::text::hint}}, out of a card's text. Each cloze number is reviewed as
+// its own face, named cN, with the deletions of that number hidden.
+package cloze
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var deletionRe = regexp.MustCompile(`\{\{c(\d+)::(.*?)(?:::(.*?))?\}\}`)
+
+// Deletion is a span of text hidden on the face of its number.
+type Deletion struct {
+	Number int
+	Text   string
+	Hint   string
+}
+
+// Face returns the name of the face of the cloze number.
+func Face(n int) string {
+	return "c" + strconv.Itoa(n)
+}
+
+// Parse returns the deletions in the text, in the order they appear.
+func Parse(text string) []Deletion {
+	deletions := []Deletion{}
+	for _, m := range deletionRe.FindAllStringSubmatch(text, -1) {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		deletions = append(deletions, Deletion{Number: n, Text: m[2], Hint: m[3]})
+	}
+	return deletions
+}
+
+// Faces returns a face for each cloze number in the text, in numeric order,
+// or nil when it has no deletions.
+func Faces(text string) []string {
+	seen := map[int]bool{}
+	numbers := []int{}
+	for _, d := range Parse(text) {
+		if !seen[d.Number] {
+			seen[d.Number] = true
+			numbers = append(numbers, d.Number)
+		}
+	}
+	sort.Ints(numbers)
+
+	var faces []string
+	for _, n := range numbers {
+		faces = append(faces, Face(n))
+	}
+	return faces
+}
+
+// Answers returns the text of each deletion on the face.
+func Answers(text, face string) []string {
+	answers := []string{}
+	for _, d := range Parse(text) {
+		if Face(d.Number) == face {
+			answers = append(answers, d.Text)
+		}
+	}
+	return answers
+}
+
+// Render replaces the deletions on the face with the result of f and every
+// other deletion with its text.
+func Render(text, face string, f func(Deletion) string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range deletionRe.FindAllStringSubmatchIndex(text, -1) {
+		b.WriteString(text[last:m[0]])
+		d := Deletion{Text: text[m[4]:m[5]]}
+		d.Number, _ = strconv.Atoi(text[m[2]:m[3]])
+		if m[6] >= 0 {
+			d.Hint = text[m[6]:m[7]]
+		}
+
+		if Face(d.Number) == face {
+			b.WriteString(f(d))
+		} else {
+			b.WriteString(d.Text)
+		}
+		last = m[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// Mask hides the deletions on the face behind their hint, or "..." when
+// they have none.
+func Mask(text, face string) string {
+	return Render(text, face, func(d Deletion) string {
+		if d.Hint != "" {
+			return "[" + d.Hint + "]"
+		}
+		return "[...]"
+	})
+}
+
+// Reveal shows the text of every deletion.
+func Reveal(text, face string) string {
+	return Render(text, face, func(d Deletion) string {
+		return d.Text
+	})
+}
@@ -0,0 +1,124 @@
+package cloze
+
+import (
+	"reflect"
+	"testing"
+)
+
+const text = "The {{c1::scheduler}} runs in {{c2::its own goroutine::where}}, {{c1::once}}"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Deletion
+	}{
+		{
+			name: "Normal",
+			text: text,
+			want: []Deletion{
+				{Number: 1, Text: "scheduler"},
+				{Number: 2, Text: "its own goroutine", Hint: "where"},
+				{Number: 1, Text: "once"},
+			},
+		},
+		{
+			name: "No Deletions",
+			text: "The scheduler runs in {{its own goroutine}}",
+			want: []Deletion{},
+		},
+		{
+			name: "Invalid Number",
+			text: "{{cx::scheduler}} {{c::goroutine}}",
+			want: []Deletion{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.text)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect deletions. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFaces(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "Normal",
+			text: text,
+			want: []string{"c1", "c2"},
+		},
+		{
+			name: "Numeric Order",
+			text: "{{c10::a}} {{c2::b}} {{c1::c}}",
+			want: []string{"c1", "c2", "c10"},
+		},
+		{
+			name: "No Deletions",
+			text: "The scheduler",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Faces(tt.text)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect faces. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name        string
+		face        string
+		wantMask    string
+		wantReveal  string
+		wantAnswers []string
+	}{
+		{
+			name:        "First Cloze",
+			face:        "c1",
+			wantMask:    "The [...] runs in its own goroutine, [...]",
+			wantReveal:  "The scheduler runs in its own goroutine, once",
+			wantAnswers: []string{"scheduler", "once"},
+		},
+		{
+			name:        "Hint",
+			face:        "c2",
+			wantMask:    "The scheduler runs in [where], once",
+			wantReveal:  "The scheduler runs in its own goroutine, once",
+			wantAnswers: []string{"its own goroutine"},
+		},
+		{
+			name:        "Unknown Face",
+			face:        "c3",
+			wantMask:    "The scheduler runs in its own goroutine, once",
+			wantReveal:  "The scheduler runs in its own goroutine, once",
+			wantAnswers: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mask(text, tt.face); got != tt.wantMask {
+				t.Errorf("Incorrect mask. Want %q, got %q", tt.wantMask, got)
+			}
+			if got := Reveal(text, tt.face); got != tt.wantReveal {
+				t.Errorf("Incorrect reveal. Want %q, got %q", tt.wantReveal, got)
+			}
+			if got := Answers(text, tt.face); !reflect.DeepEqual(tt.wantAnswers, got) {
+				t.Errorf("Incorrect answers. Want %v, got %v", tt.wantAnswers, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package configuring
+
+import "time"
+
+// Unlimited removes a daily limit that would otherwise be inherited.
+const Unlimited = -1
+
+type Config struct {
+	Scheduler string
+	Weights   []float64
+	Retention float64
+	Boxes     []int
+	// NewPerDay and ReviewsPerDay limit how many new and previously
+	// seen cards are studied each day.
+	NewPerDay     int
+	ReviewsPerDay int
+	// LeechThreshold is the number of lapses that make a card a leech, and
+	// LeechAction whether a leech is only tagged or also suspended.
+	LeechThreshold int
+	LeechAction    string
+	// Target is a date, such as an exam, by which every card should be
+	// reviewed TargetReviews more times.
+	Target        time.Time
+	TargetReviews int
+}
+
+// Inherit fills the unset settings of c with those of p.
+func (c Config) Inherit(p Config) Config {
+	if c.Scheduler == "" {
+		c.Scheduler = p.Scheduler
+	}
+	if c.Weights == nil {
+		c.Weights = p.Weights
+	}
+	if c.Retention == 0 {
+		c.Retention = p.Retention
+	}
+	if c.Boxes == nil {
+		c.Boxes = p.Boxes
+	}
+	if c.NewPerDay == 0 {
+		c.NewPerDay = p.NewPerDay
+	}
+	if c.ReviewsPerDay == 0 {
+		c.ReviewsPerDay = p.ReviewsPerDay
+	}
+	if c.LeechThreshold == 0 {
+		c.LeechThreshold = p.LeechThreshold
+	}
+	if c.LeechAction == "" {
+		c.LeechAction = p.LeechAction
+	}
+	if c.Target.IsZero() {
+		c.Target = p.Target
+	}
+	if c.TargetReviews == 0 {
+		c.TargetReviews = p.TargetReviews
+	}
+	return c
+}
@@ -0,0 +1,33 @@
+package configuring
+
+import "strings"
+
+// Lineage returns the group followed by its parent groups, nearest first,
+// ending with the root group "". A setting left unset on a group is
+// inherited from the first group in its lineage that sets it.
+func Lineage(g string) []string {
+	groups := []string{g}
+	for g != "" {
+		i := strings.LastIndex(g, ".")
+		if i < 0 {
+			i = 0
+		}
+		g = g[:i]
+		groups = append(groups, g)
+	}
+	return groups
+}
+
+// Resolve returns the group's config with each setting it leaves unset
+// inherited through its lineage, reading the config of each group by get.
+func Resolve(get func(string) (Config, error), g string) (Config, error) {
+	var c Config
+	for _, g := range Lineage(g) {
+		p, err := get(g)
+		if err != nil {
+			return Config{}, err
+		}
+		c = c.Inherit(p)
+	}
+	return c, nil
+}
@@ -0,0 +1,84 @@
+package configuring
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLineage(t *testing.T) {
+	tests := []struct {
+		name  string
+		group string
+		want  []string
+	}{
+		{name: "Root", group: "", want: []string{""}},
+		{name: "Top Level", group: "Group", want: []string{"Group", ""}},
+		{
+			name:  "Nested",
+			group: "Group.SubGroup.Leaf",
+			want:  []string{"Group.SubGroup.Leaf", "Group.SubGroup", "Group", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Lineage(tt.group); !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect lineage. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	target := time.Date(2022, time.December, 1, 0, 0, 0, 0, time.UTC)
+	configs := map[string]Config{
+		"":      {NewPerDay: 20, LeechThreshold: 5},
+		"Group": {Scheduler: "fsrs", Retention: 0.9, NewPerDay: Unlimited, Target: target},
+		"Group.SubGroup": {
+			Scheduler: "leitner", Boxes: []int{1, 2, 5}, ReviewsPerDay: 50,
+		},
+	}
+	tests := []struct {
+		name  string
+		group string
+		want  Config
+	}{
+		{
+			name:  "Root",
+			group: "",
+			want:  Config{NewPerDay: 20, LeechThreshold: 5},
+		},
+		{
+			name:  "Top Level",
+			group: "Group",
+			want: Config{
+				Scheduler: "fsrs", Retention: 0.9, NewPerDay: Unlimited,
+				LeechThreshold: 5, Target: target,
+			},
+		},
+		{
+			name:  "Nested",
+			group: "Group.SubGroup.Leaf",
+			want: Config{
+				Scheduler: "leitner", Retention: 0.9, Boxes: []int{1, 2, 5},
+				NewPerDay: Unlimited, ReviewsPerDay: 50, LeechThreshold: 5, Target: target,
+			},
+		},
+	}
+
+	get := func(g string) (Config, error) {
+		return configs[g], nil
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resolve(get, tt.group)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect config. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
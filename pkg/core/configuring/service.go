@@ -0,0 +1,60 @@
+package configuring
+
+import "errors"
+
+var (
+	ErrInvalidLimit     error = errors.New("daily limits must be positive or unlimited")
+	ErrInvalidThreshold error = errors.New("leech threshold must be positive")
+	ErrInvalidReviews   error = errors.New("target reviews must be positive")
+)
+
+type Service interface {
+	GetConfig(string) (Config, error)
+	SetConfig(string, Config) error
+}
+
+type Repository interface {
+	GetConfig(string) (Config, error)
+	SetConfig(string, Config) error
+}
+
+// Validator checks the settings of a config that belong to another service,
+// such as the schedulers of the reviewing service, so that service alone
+// decides which are valid.
+type Validator func(Config) error
+
+type service struct {
+	r Repository
+	v Validator
+}
+
+func New(r Repository, v Validator) *service {
+	return &service{r, v}
+}
+
+func (s *service) GetConfig(g string) (Config, error) {
+	return s.r.GetConfig(g)
+}
+
+func (s *service) SetConfig(g string, c Config) error {
+	if err := validate(c); err != nil {
+		return err
+	}
+	if err := s.v(c); err != nil {
+		return err
+	}
+	return s.r.SetConfig(g, c)
+}
+
+func validate(c Config) error {
+	if c.NewPerDay < Unlimited || c.ReviewsPerDay < Unlimited {
+		return ErrInvalidLimit
+	}
+	if c.LeechThreshold < 0 {
+		return ErrInvalidThreshold
+	}
+	if c.TargetReviews < 0 {
+		return ErrInvalidReviews
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package configuring
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var errUnknownScheduler error = errors.New("unknown scheduler")
+
+// validateStub stands in for the reviewing service's validation, knowing
+// only the schedulers used here.
+func validateStub(c Config) error {
+	switch c.Scheduler {
+	case "", "sm2", "fsrs", "leitner":
+		return nil
+	}
+	return errUnknownScheduler
+}
+
+type repositoryStub struct {
+	configs map[string]Config
+}
+
+func newRepositoryStubWithConfigs() *repositoryStub {
+	return &repositoryStub{
+		configs: map[string]Config{
+			"Group": {Scheduler: "fsrs", Retention: 0.9},
+		},
+	}
+}
+
+func (r *repositoryStub) GetConfig(g string) (Config, error) {
+	return r.configs[g], nil
+}
+
+func (r *repositoryStub) SetConfig(g string, c Config) error {
+	r.configs[g] = c
+	return nil
+}
+
+func TestGetConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		want    Config
+		wantErr error
+	}{
+		{
+			name:    "Normal",
+			group:   "Group",
+			want:    Config{Scheduler: "fsrs", Retention: 0.9},
+			wantErr: nil,
+		},
+		{
+			name:    "Unset",
+			group:   "Group.SubGroup",
+			want:    Config{},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepositoryStubWithConfigs()
+			cs := New(repo, validateStub)
+			got, err := cs.GetConfig(tt.group)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect config. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSetConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		config  Config
+		want    map[string]Config
+		wantErr error
+	}{
+		{
+			name:   "Normal",
+			group:  "Group.SubGroup",
+			config: Config{Scheduler: "sm2"},
+			want: map[string]Config{
+				"Group":          {Scheduler: "fsrs", Retention: 0.9},
+				"Group.SubGroup": {Scheduler: "sm2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Inherit",
+			group:  "Group.SubGroup",
+			config: Config{},
+			want: map[string]Config{
+				"Group":          {Scheduler: "fsrs", Retention: 0.9},
+				"Group.SubGroup": {},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Unknown Scheduler",
+			group:  "Group",
+			config: Config{Scheduler: "unknown"},
+			want: map[string]Config{
+				"Group": {Scheduler: "fsrs", Retention: 0.9},
+			},
+			wantErr: errUnknownScheduler,
+		},
+		{
+			name:   "Leitner",
+			group:  "Group",
+			config: Config{Scheduler: "leitner", Boxes: []int{1, 3, 7}},
+			want: map[string]Config{
+				"Group": {Scheduler: "leitner", Boxes: []int{1, 3, 7}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Limits",
+			group:  "Group.SubGroup",
+			config: Config{NewPerDay: 10, ReviewsPerDay: Unlimited},
+			want: map[string]Config{
+				"Group":          {Scheduler: "fsrs", Retention: 0.9},
+				"Group.SubGroup": {NewPerDay: 10, ReviewsPerDay: Unlimited},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Invalid Limit",
+			group:  "Group",
+			config: Config{NewPerDay: -2},
+			want: map[string]Config{
+				"Group": {Scheduler: "fsrs", Retention: 0.9},
+			},
+			wantErr: ErrInvalidLimit,
+		},
+		{
+			name:   "Leech",
+			group:  "Group",
+			config: Config{LeechThreshold: 4, LeechAction: "suspend"},
+			want: map[string]Config{
+				"Group": {LeechThreshold: 4, LeechAction: "suspend"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Target",
+			group:  "Group",
+			config: Config{Target: time.Date(2022, time.December, 1, 0, 0, 0, 0, time.UTC), TargetReviews: 3},
+			want: map[string]Config{
+				"Group": {Target: time.Date(2022, time.December, 1, 0, 0, 0, 0, time.UTC), TargetReviews: 3},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Invalid Target Reviews",
+			group:  "Group",
+			config: Config{TargetReviews: -1},
+			want: map[string]Config{
+				"Group": {Scheduler: "fsrs", Retention: 0.9},
+			},
+			wantErr: ErrInvalidReviews,
+		},
+		{
+			name:   "Invalid Leech Threshold",
+			group:  "Group",
+			config: Config{LeechThreshold: -1},
+			want: map[string]Config{
+				"Group": {Scheduler: "fsrs", Retention: 0.9},
+			},
+			wantErr: ErrInvalidThreshold,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepositoryStubWithConfigs()
+			cs := New(repo, validateStub)
+			err := cs.SetConfig(tt.group, tt.config)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, repo.configs) {
+				t.Errorf("Incorrect repo.configs. Want %v, got %v", tt.want, repo.configs)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package deleting
 
+// Card is found by its ID when it is set, or else by its title in the group.
 type Card struct {
+	ID    string
 	Title string
 }
@@ -21,7 +21,7 @@ func New(r Repository) *service {
 }
 
 func (s *service) DeleteCard(g string, c Card) error {
-	if c.Title == "" {
+	if c.ID == "" && c.Title == "" {
 		return ErrCardEmptyTitle
 	}
 	return s.r.DeleteCard(g, c)
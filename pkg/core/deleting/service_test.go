@@ -32,7 +32,7 @@ func (r *repositoryStub) DeleteCard(g string, c Card) error {
 
 	index := -1
 	for i, card := range r.cards {
-		if c.Title == card.Title {
+		if (c.ID != "" && c.ID == card.ID) || (c.ID == "" && c.Title == card.Title) {
 			index = i
 		}
 	}
@@ -138,3 +138,57 @@ func TestDeleteCard(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteCardByID(t *testing.T) {
+	tests := []struct {
+		name    string
+		card    Card
+		want    []Card
+		wantErr error
+	}{
+		{
+			name: "Normal",
+			card: Card{ID: "9b29587ef2017333"},
+			want: []Card{
+				{Title: "Subject1"},
+				{Title: "Subject2"},
+				{Title: "Group.Subject1"},
+				{Title: "Group.Subject2"},
+				{Title: "Group.SubGroup.Subject1"},
+				{Title: "Group.SubGroup.Subject2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Card Not Found",
+			card: Card{ID: "0000000000000000", Title: "Group.Subject3"},
+			want: []Card{
+				{Title: "Subject1"},
+				{Title: "Subject2"},
+				{Title: "Group.Subject1"},
+				{Title: "Group.Subject2"},
+				{Title: "Group.SubGroup.Subject1"},
+				{Title: "Group.SubGroup.Subject2"},
+				{ID: "9b29587ef2017333", Title: "Group.Subject3"},
+			},
+			wantErr: errCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepositoryStubWithCards()
+			repo.cards = append(repo.cards, Card{ID: "9b29587ef2017333", Title: "Group.Subject3"})
+			ds := New(repo)
+			err := ds.DeleteCard("", tt.card)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, repo.cards) {
+				t.Errorf("Incorrect repo.cards. Want %v, got %v", tt.want, repo.cards)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package exporting
+
+import "github.com/jmcveigh55/flash/pkg/core/getting"
+
+// Collection is the cards under a group, with the content of the media
+// attached to them keyed by the file each is stored under.
+type Collection struct {
+	Cards []getting.Card
+	Media map[string][]byte
+}
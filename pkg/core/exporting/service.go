@@ -0,0 +1,46 @@
+package exporting
+
+import "github.com/jmcveigh55/flash/pkg/core/getting"
+
+// Service exports the cards under a group along with their media, for the
+// collection to be backed up or moved with its attachments.
+type Service interface {
+	Export(string) (*Collection, error)
+}
+
+type Repository interface {
+	ReadMedia(string) ([]byte, error)
+}
+
+type service struct {
+	g getting.Service
+	r Repository
+}
+
+func New(g getting.Service, r Repository) *service {
+	return &service{g, r}
+}
+
+// Export returns every card under the group, suspended or not, and each
+// media file attached to them once, however many cards share it.
+func (s *service) Export(g string) (*Collection, error) {
+	cards, err := s.g.GetAllCards(g, getting.All)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Collection{Cards: cards, Media: map[string][]byte{}}
+	for _, card := range cards {
+		for _, m := range card.Media {
+			if _, ok := c.Media[m.File]; ok {
+				continue
+			}
+			data, err := s.r.ReadMedia(m.File)
+			if err != nil {
+				return nil, err
+			}
+			c.Media[m.File] = data
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,126 @@
+package exporting
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+)
+
+var (
+	errGroupNotFound error = errors.New("group not found")
+	errMediaNotFound error = errors.New("media not found")
+)
+
+// storeStub is both the getting service and the repository.
+type storeStub struct {
+	cards []getting.Card
+	media map[string][]byte
+}
+
+func newStoreStubWithCards() *storeStub {
+	return &storeStub{
+		cards: []getting.Card{
+			{Title: "Group.Subject1", Desc: "Value1", Media: []getting.Media{{Name: "a.png", File: "used.png"}}},
+			{Title: "Group.Subject2", Desc: "Value2", Media: []getting.Media{{Name: "b.png", File: "used.png"}}},
+			{Title: "Group.Subject3", Desc: "Value3", Suspended: true, Media: []getting.Media{{Name: "c.txt", File: "other.txt"}}},
+			{Title: "Other.Subject1", Desc: "Value4", Media: []getting.Media{{Name: "d.png", File: "missing.png"}}},
+		},
+		media: map[string][]byte{
+			"used.png":   []byte("a"),
+			"other.txt":  []byte("c"),
+			"unused.png": []byte("b"),
+		},
+	}
+}
+
+func (s *storeStub) GetCards(string, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (s *storeStub) GetAllCards(g string, _ getting.Filter) ([]getting.Card, error) {
+	var cards []getting.Card
+	for _, c := range s.cards {
+		if g == "" || strings.HasPrefix(c.Title, g+".") {
+			cards = append(cards, c)
+		}
+	}
+	if len(cards) == 0 {
+		return nil, errGroupNotFound
+	}
+	return cards, nil
+}
+
+func (s *storeStub) GetCardsInBox(string, int, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (s *storeStub) GetLeeches(string) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (s *storeStub) ReadMedia(file string) ([]byte, error) {
+	data, ok := s.media[file]
+	if !ok {
+		return nil, errMediaNotFound
+	}
+	return data, nil
+}
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		name      string
+		group     string
+		wantCards []string
+		wantMedia map[string][]byte
+		wantErr   error
+	}{
+		{
+			name:      "Normal",
+			group:     "Group",
+			wantCards: []string{"Group.Subject1", "Group.Subject2", "Group.Subject3"},
+			wantMedia: map[string][]byte{
+				"used.png":  []byte("a"),
+				"other.txt": []byte("c"),
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Missing Media",
+			group:   "Other",
+			wantErr: errMediaNotFound,
+		},
+		{
+			name:    "Group Not Found",
+			group:   "NotFound",
+			wantErr: errGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := New(newStoreStubWithCards(), newStoreStubWithCards())
+			c, err := es.Export(tt.group)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			var titles []string
+			for _, card := range c.Cards {
+				titles = append(titles, card.Title)
+			}
+			if !reflect.DeepEqual(tt.wantCards, titles) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.wantCards, titles)
+			}
+			if !reflect.DeepEqual(tt.wantMedia, c.Media) {
+				t.Errorf("Incorrect media. Want %v, got %v", tt.wantMedia, c.Media)
+			}
+		})
+	}
+}
@@ -1,6 +1,141 @@
 package getting
 
+import (
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/cloze"
+)
+
+// Faces of a card, each reviewed on its own schedule.
+const (
+	Forward = "forward"
+	Reverse = "reverse"
+)
+
 type Card struct {
-	Title string
-	Desc  string
+	// ID stays the same for as long as the card exists, whatever its title.
+	ID         string
+	Title      string
+	Desc       string
+	Answers    []string
+	Reversible bool
+	// Cloze cards hide the deletions marked in their description instead,
+	// with a face per cloze number.
+	Cloze      bool
+	Created    time.Time
+	Updated    time.Time
+	Schedules  map[string]Schedule
+	Suspended  bool
+	BuriedTill time.Time
+	// Leech is set once a face lapses more often than its group allows.
+	Leech bool
+	Tags  []string
+	// Note cards fill their type's templates with their fields instead,
+	// with a face per template.
+	Note *Note
+	// Media are the files attached to the card.
+	Media []Media
+	// Revisions hold the content the card had before each update, oldest
+	// first.
+	Revisions []Revision
+}
+
+// Revision is the content a card had from the time until its next update.
+type Revision struct {
+	Desc    string
+	Answers []string
+	Time    time.Time
+}
+
+// Media is a file attached to a card, under its original name and the name
+// it is stored under.
+type Media struct {
+	Name string
+	File string
+}
+
+// Note holds the fields of a note card and the templates of its type.
+type Note struct {
+	Type      string
+	Fields    map[string]string
+	Templates []Template
+}
+
+type Template struct {
+	Name  string
+	Front string
+	Back  string
+}
+
+// Template returns the note's template for the face.
+func (n Note) Template(f string) (Template, bool) {
+	for _, t := range n.Templates {
+		if t.Name == f {
+			return t, true
+		}
+	}
+	return Template{}, false
+}
+
+type Schedule struct {
+	Due      time.Time
+	Interval int
+	Box      int
+	Lapses   int
+}
+
+// Faces returns the faces of the card that are reviewed. Every card is
+// reviewed forward, from title to description, and reversible cards are
+// also reviewed from description to title. Cloze cards are reviewed once
+// per cloze number, and note cards once per template, instead.
+func (c Card) Faces() []string {
+	if c.Note != nil {
+		var faces []string
+		for _, t := range c.Note.Templates {
+			faces = append(faces, t.Name)
+		}
+		return faces
+	}
+	if c.Cloze {
+		return cloze.Faces(c.Desc)
+	}
+	if c.Reversible {
+		return []string{Forward, Reverse}
+	}
+	return []string{Forward}
+}
+
+// HasFace reports whether f is one of the faces the card is reviewed on.
+func (c Card) HasFace(f string) bool {
+	for _, face := range c.Faces() {
+		if face == f {
+			return true
+		}
+	}
+	return false
+}
+
+// Schedule returns the schedule of the face. A face that has not been
+// reviewed yet is due from when the card was created.
+func (c Card) Schedule(f string) Schedule {
+	if s, ok := c.Schedules[f]; ok {
+		return s
+	}
+	return Schedule{Due: c.Created}
+}
+
+// Buried reports whether the card is buried at t. A buried card is left out
+// of study sessions until the day it was buried for has passed.
+func (c Card) Buried(t time.Time) bool {
+	return t.Before(c.BuriedTill)
+}
+
+// Lapses returns how many times the card's faces were forgotten after being
+// learned.
+func (c Card) Lapses() int {
+	lapses := 0
+	for _, s := range c.Schedules {
+		lapses += s.Lapses
+	}
+	return lapses
 }
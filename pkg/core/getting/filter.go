@@ -0,0 +1,33 @@
+package getting
+
+// Filter selects cards by whether they are suspended.
+type Filter int
+
+const (
+	Unsuspended Filter = iota // Only cards that are not suspended
+	Suspended                 // Only suspended cards
+	All                       // Every card
+)
+
+func (f Filter) matches(c Card) bool {
+	switch f {
+	case Unsuspended:
+		return !c.Suspended
+	case Suspended:
+		return c.Suspended
+	}
+	return true
+}
+
+func (f Filter) apply(cards []Card) []Card {
+	if f == All {
+		return cards
+	}
+	filtered := []Card{}
+	for _, c := range cards {
+		if f.matches(c) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
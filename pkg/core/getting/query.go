@@ -0,0 +1,237 @@
+package getting
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidQuery error = errors.New("invalid tag query")
+
+// Query selects cards by their tags. Tags are combined with "and" ("+",
+// "&" or a space), "or" ("," or "|") and "not" ("!"), with "not" binding
+// tightest and "or" loosest, and may be grouped in parentheses. The zero
+// Query selects every card.
+type Query struct {
+	root node
+}
+
+type node interface {
+	matches(map[string]bool) bool
+}
+
+type tagNode string
+
+func (n tagNode) matches(tags map[string]bool) bool {
+	return tags[string(n)]
+}
+
+type notNode struct {
+	n node
+}
+
+func (n notNode) matches(tags map[string]bool) bool {
+	return !n.n.matches(tags)
+}
+
+type andNode struct {
+	l, r node
+}
+
+func (n andNode) matches(tags map[string]bool) bool {
+	return n.l.matches(tags) && n.r.matches(tags)
+}
+
+type orNode struct {
+	l, r node
+}
+
+func (n orNode) matches(tags map[string]bool) bool {
+	return n.l.matches(tags) || n.r.matches(tags)
+}
+
+// ValidTag reports whether t can be used as a tag. Tags are made of
+// letters, digits and "_-./:", and cannot be one of the query's keywords.
+func ValidTag(t string) bool {
+	if t == "" || keyword(t) != "" {
+		return false
+	}
+	for _, r := range t {
+		if !isTagRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isTagRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-./:", r)
+}
+
+// keyword returns the operator the word stands for, if any.
+func keyword(w string) string {
+	switch strings.ToLower(w) {
+	case "and":
+		return "+"
+	case "or":
+		return ","
+	case "not":
+		return "!"
+	}
+	return ""
+}
+
+// ParseQuery parses a tag query. An empty string selects every card.
+func ParseQuery(s string) (Query, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return Query{}, err
+	}
+	if len(tokens) == 0 {
+		return Query{}, nil
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.or()
+	if err != nil {
+		return Query{}, err
+	}
+	if p.pos != len(p.tokens) {
+		return Query{}, ErrInvalidQuery
+	}
+	return Query{root}, nil
+}
+
+// tokenize splits s into tags and operators, replacing keywords by their
+// operator.
+func tokenize(s string) ([]string, error) {
+	var tokens []string
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("()+&,|!", r):
+			tokens = append(tokens, string(r))
+			i++
+		case isTagRune(r):
+			j := i
+			for j < len(runes) && isTagRune(runes[j]) {
+				j++
+			}
+			word := string(runes[i:j])
+			if op := keyword(word); op != "" {
+				word = op
+			}
+			tokens = append(tokens, word)
+			i = j
+		default:
+			return nil, ErrInvalidQuery
+		}
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *parser) or() (node, error) {
+	l, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for t := p.peek(); t == "," || t == "|"; t = p.peek() {
+		p.pos++
+		r, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l = orNode{l, r}
+	}
+	return l, nil
+}
+
+// and also joins terms written next to each other.
+func (p *parser) and() (node, error) {
+	l, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch t := p.peek(); t {
+		case "+", "&":
+			p.pos++
+		case "", ")", ",", "|":
+			return l, nil
+		}
+		r, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		l = andNode{l, r}
+	}
+}
+
+func (p *parser) not() (node, error) {
+	switch t := p.peek(); t {
+	case "!":
+		p.pos++
+		n, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	case "(":
+		p.pos++
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, ErrInvalidQuery
+		}
+		p.pos++
+		return n, nil
+	case "", ")", "+", "&", ",", "|":
+		return nil, ErrInvalidQuery
+	default:
+		p.pos++
+		return tagNode(t), nil
+	}
+}
+
+// Matches reports whether the card's tags satisfy the query.
+func (q Query) Matches(c Card) bool {
+	if q.root == nil {
+		return true
+	}
+	tags := map[string]bool{}
+	for _, t := range c.Tags {
+		tags[t] = true
+	}
+	return q.root.matches(tags)
+}
+
+// Select returns the cards that match the query.
+func (q Query) Select(cards []Card) []Card {
+	if q.root == nil {
+		return cards
+	}
+	selected := []Card{}
+	for _, c := range cards {
+		if q.Matches(c) {
+			selected = append(selected, c)
+		}
+	}
+	return selected
+}
@@ -0,0 +1,75 @@
+package getting
+
+import (
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		tags    []string
+		want    bool
+		wantErr error
+	}{
+		{"Empty", "", nil, true, nil},
+		{"Tag", "go", []string{"go"}, true, nil},
+		{"Tag Missing", "go", []string{"rust"}, false, nil},
+		{"And", "go+concurrency", []string{"go", "concurrency"}, true, nil},
+		{"And Missing", "go & concurrency", []string{"go"}, false, nil},
+		{"And Words", "go and concurrency", []string{"go", "concurrency"}, true, nil},
+		{"And Space", "go concurrency", []string{"concurrency"}, false, nil},
+		{"Or", "go,rust", []string{"rust"}, true, nil},
+		{"Or Words", "go OR rust", []string{"c"}, false, nil},
+		{"Not", "!old", []string{"go"}, true, nil},
+		{"Not Words", "go and not old", []string{"go", "old"}, false, nil},
+		{"Precedence", "go,rust+!old", []string{"go", "old"}, true, nil},
+		{"Parentheses", "(go,rust)+!old", []string{"go", "old"}, false, nil},
+		{"Nested", "!(go|rust)", []string{"c"}, true, nil},
+		{"Tag Characters", "lang/go:1.21", []string{"lang/go:1.21"}, true, nil},
+		{"Unclosed", "(go,rust", nil, false, ErrInvalidQuery},
+		{"Unopened", "go)", nil, false, ErrInvalidQuery},
+		{"Missing Operand", "go+", nil, false, ErrInvalidQuery},
+		{"Leading Operator", ",go", nil, false, ErrInvalidQuery},
+		{"Invalid Character", "go#", nil, false, ErrInvalidQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := q.Matches(Card{Tags: tt.tags}); got != tt.want {
+				t.Errorf("Incorrect match. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want bool
+	}{
+		{"go", true},
+		{"lang/go-1.21", true},
+		{"", false},
+		{"two words", false},
+		{"go+rust", false},
+		{"not", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := ValidTag(tt.tag); got != tt.want {
+				t.Errorf("Incorrect validity. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
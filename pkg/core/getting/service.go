@@ -1,8 +1,19 @@
 package getting
 
+import (
+	"errors"
+	"sort"
+)
+
+// ErrGroupNotFound is returned by repositories for a group without any
+// cards, the root group included once every card is deleted.
+var ErrGroupNotFound error = errors.New("group not found")
+
 type Service interface {
-	GetCards(string) ([]Card, error)
-	GetAllCards(string) ([]Card, error)
+	GetCards(string, Filter) ([]Card, error)
+	GetAllCards(string, Filter) ([]Card, error)
+	GetCardsInBox(string, int, Filter) ([]Card, error)
+	GetLeeches(string) ([]Card, error)
 }
 
 type Repository interface {
@@ -18,10 +29,58 @@ func New(r Repository) *service {
 	return &service{r}
 }
 
-func (s *service) GetCards(g string) ([]Card, error) {
-	return s.r.GetCards(g)
+func (s *service) GetCards(g string, f Filter) ([]Card, error) {
+	cards, err := s.r.GetCards(g)
+	if err != nil {
+		return cards, err
+	}
+	return f.apply(cards), nil
+}
+
+func (s *service) GetAllCards(g string, f Filter) ([]Card, error) {
+	cards, err := s.r.GetAllCards(g)
+	if err != nil {
+		return cards, err
+	}
+	return f.apply(cards), nil
 }
 
-func (s *service) GetAllCards(g string) ([]Card, error) {
-	return s.r.GetAllCards(g)
+// GetCardsInBox returns the cards under the group with a face in the
+// Leitner box. Box 0 holds the faces that have not been placed in a box yet.
+func (s *service) GetCardsInBox(g string, b int, f Filter) ([]Card, error) {
+	cards, err := s.GetAllCards(g, f)
+	if err != nil {
+		return cards, err
+	}
+
+	boxed := []Card{}
+	for _, c := range cards {
+		for _, f := range c.Faces() {
+			if c.Schedule(f).Box == b {
+				boxed = append(boxed, c)
+				break
+			}
+		}
+	}
+	return boxed, nil
+}
+
+// GetLeeches returns the leeches under the group, suspended or not, with the
+// most lapses first.
+func (s *service) GetLeeches(g string) ([]Card, error) {
+	cards, err := s.r.GetAllCards(g)
+	if err != nil {
+		return cards, err
+	}
+
+	leeches := []Card{}
+	for _, c := range cards {
+		if c.Leech {
+			leeches = append(leeches, c)
+		}
+	}
+	sort.SliceStable(leeches, func(i, j int) bool {
+		return leeches[i].Lapses() > leeches[j].Lapses()
+	})
+	return leeches, nil
 }
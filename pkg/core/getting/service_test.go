@@ -20,8 +20,15 @@ func newRepositoryStubWithCards() *repositoryStub {
 			{Title: "Subject2", Desc: "Value2"},
 			{Title: "Group.Subject1", Desc: "Value1"},
 			{Title: "Group.Subject2", Desc: "Value2"},
-			{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-			{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			{Title: "Group.Subject3", Desc: "Value3", Suspended: true},
+			{
+				Title: "Group.SubGroup.Subject1", Desc: "Value1", Reversible: true,
+				Schedules: map[string]Schedule{Forward: {Box: 1}, Reverse: {Box: 2}},
+			},
+			{
+				Title: "Group.SubGroup.Subject2", Desc: "Value2",
+				Schedules: map[string]Schedule{Forward: {Box: 1}},
+			},
 		},
 	}
 }
@@ -58,6 +65,7 @@ func TestGetCards(t *testing.T) {
 	tests := []struct {
 		group   string
 		name    string
+		filter  Filter
 		want    []Card
 		wantErr error
 	}{
@@ -70,12 +78,38 @@ func TestGetCards(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name:   "Suspended",
+			group:  "Group",
+			filter: Suspended,
+			want: []Card{
+				{Title: "Group.Subject3", Desc: "Value3", Suspended: true},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "All",
+			group:  "Group",
+			filter: All,
+			want: []Card{
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.Subject3", Desc: "Value3", Suspended: true},
+			},
+			wantErr: nil,
+		},
 		{
 			name:  "SubGroup",
 			group: "Group.SubGroup",
 			want: []Card{
-				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{
+					Title: "Group.SubGroup.Subject1", Desc: "Value1", Reversible: true,
+					Schedules: map[string]Schedule{Forward: {Box: 1}, Reverse: {Box: 2}},
+				},
+				{
+					Title: "Group.SubGroup.Subject2", Desc: "Value2",
+					Schedules: map[string]Schedule{Forward: {Box: 1}},
+				},
 			},
 			wantErr: nil,
 		},
@@ -100,7 +134,7 @@ func TestGetCards(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := newRepositoryStubWithCards()
 			gs := New(repo)
-			got, err := gs.GetCards(tt.group)
+			got, err := gs.GetCards(tt.group, tt.filter)
 
 			if err != tt.wantErr {
 				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
@@ -117,6 +151,7 @@ func TestGetAllCards(t *testing.T) {
 	tests := []struct {
 		group   string
 		name    string
+		filter  Filter
 		want    []Card
 		wantErr error
 	}{
@@ -126,8 +161,14 @@ func TestGetAllCards(t *testing.T) {
 			want: []Card{
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
-				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{
+					Title: "Group.SubGroup.Subject1", Desc: "Value1", Reversible: true,
+					Schedules: map[string]Schedule{Forward: {Box: 1}, Reverse: {Box: 2}},
+				},
+				{
+					Title: "Group.SubGroup.Subject2", Desc: "Value2",
+					Schedules: map[string]Schedule{Forward: {Box: 1}},
+				},
 			},
 			wantErr: nil,
 		},
@@ -135,8 +176,23 @@ func TestGetAllCards(t *testing.T) {
 			name:  "SubGroup",
 			group: "Group.SubGroup",
 			want: []Card{
-				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{
+					Title: "Group.SubGroup.Subject1", Desc: "Value1", Reversible: true,
+					Schedules: map[string]Schedule{Forward: {Box: 1}, Reverse: {Box: 2}},
+				},
+				{
+					Title: "Group.SubGroup.Subject2", Desc: "Value2",
+					Schedules: map[string]Schedule{Forward: {Box: 1}},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Suspended",
+			group:  "Group",
+			filter: Suspended,
+			want: []Card{
+				{Title: "Group.Subject3", Desc: "Value3", Suspended: true},
 			},
 			wantErr: nil,
 		},
@@ -148,8 +204,14 @@ func TestGetAllCards(t *testing.T) {
 				{Title: "Subject2", Desc: "Value2"},
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
-				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{
+					Title: "Group.SubGroup.Subject1", Desc: "Value1", Reversible: true,
+					Schedules: map[string]Schedule{Forward: {Box: 1}, Reverse: {Box: 2}},
+				},
+				{
+					Title: "Group.SubGroup.Subject2", Desc: "Value2",
+					Schedules: map[string]Schedule{Forward: {Box: 1}},
+				},
 			},
 			wantErr: nil,
 		},
@@ -165,7 +227,7 @@ func TestGetAllCards(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := newRepositoryStubWithCards()
 			gs := New(repo)
-			got, err := gs.GetAllCards(tt.group)
+			got, err := gs.GetAllCards(tt.group, tt.filter)
 
 			if err != tt.wantErr {
 				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
@@ -177,3 +239,153 @@ func TestGetAllCards(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCardsInBox(t *testing.T) {
+	tests := []struct {
+		group   string
+		name    string
+		box     int
+		filter  Filter
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			box:   2,
+			want: []Card{
+				{
+					Title: "Group.SubGroup.Subject1", Desc: "Value1", Reversible: true,
+					Schedules: map[string]Schedule{Forward: {Box: 1}, Reverse: {Box: 2}},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Either Face",
+			group: "Group",
+			box:   1,
+			want: []Card{
+				{
+					Title: "Group.SubGroup.Subject1", Desc: "Value1", Reversible: true,
+					Schedules: map[string]Schedule{Forward: {Box: 1}, Reverse: {Box: 2}},
+				},
+				{
+					Title: "Group.SubGroup.Subject2", Desc: "Value2",
+					Schedules: map[string]Schedule{Forward: {Box: 1}},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Unboxed",
+			group: "Group",
+			box:   0,
+			want: []Card{
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Unboxed Including Suspended",
+			group:  "Group",
+			box:    0,
+			filter: All,
+			want: []Card{
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.Subject3", Desc: "Value3", Suspended: true},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Empty Box",
+			group:   "Group",
+			box:     5,
+			want:    []Card{},
+			wantErr: nil,
+		},
+		{
+			name:    "Group Not Found",
+			group:   "NotFound",
+			box:     1,
+			want:    []Card{},
+			wantErr: errGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepositoryStubWithCards()
+			gs := New(repo)
+			got, err := gs.GetCardsInBox(tt.group, tt.box, tt.filter)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetLeeches(t *testing.T) {
+	repo := &repositoryStub{
+		cards: []Card{
+			{
+				Title: "Group.Subject1", Leech: true,
+				Schedules: map[string]Schedule{Forward: {Lapses: 8}},
+			},
+			{
+				Title:     "Group.Subject2",
+				Schedules: map[string]Schedule{Forward: {Lapses: 3}},
+			},
+			{
+				Title: "Group.Subject3", Leech: true, Suspended: true, Reversible: true,
+				Schedules: map[string]Schedule{Forward: {Lapses: 5}, Reverse: {Lapses: 8}},
+			},
+			{Title: "Other.Subject1", Leech: true},
+		},
+	}
+	tests := []struct {
+		name    string
+		group   string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "Normal",
+			group:   "Group",
+			want:    []string{"Group.Subject3", "Group.Subject1"},
+			wantErr: nil,
+		},
+		{
+			name:    "Group Not Found",
+			group:   "NotFound",
+			want:    []string{},
+			wantErr: errGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := New(repo)
+			cards, err := gs.GetLeeches(tt.group)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			got := []string{}
+			for _, c := range cards {
+				got = append(got, c.Title)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect leeches. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+)
+
+// Review is an entry in the review log.
+type Review struct {
+	// Card is the card's full path.
+	Card         string
+	Face         string
+	Time         time.Time
+	Grade        reviewing.Grade
+	Duration     time.Duration
+	LastInterval int
+	Interval     int
+	Scheduler    string
+}
+
+// Query selects reviews from the log. Unset fields match every review.
+type Query struct {
+	// Card matches the reviews of the card with this full path.
+	Card string
+	// Group matches the reviews of every card under this group.
+	Group string
+	// From and To match reviews from, and before, these times.
+	From time.Time
+	To   time.Time
+}
+
+// Matches reports whether the review is selected by the query.
+func (q Query) Matches(r Review) bool {
+	if q.Card != "" && r.Card != q.Card {
+		return false
+	}
+	if q.Group != "" && !strings.HasPrefix(r.Card, q.Group+".") {
+		return false
+	}
+	if !q.From.IsZero() && r.Time.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !r.Time.Before(q.To) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package logging
+
+import "sort"
+
+type Service interface {
+	GetReviews(Query) ([]Review, error)
+}
+
+type Repository interface {
+	GetReviews() ([]Review, error)
+}
+
+type service struct {
+	r Repository
+}
+
+func New(r Repository) *service {
+	return &service{r}
+}
+
+// GetReviews returns the reviews selected by the query, oldest first.
+func (s *service) GetReviews(q Query) ([]Review, error) {
+	all, err := s.r.GetReviews()
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := []Review{}
+	for _, r := range all {
+		if q.Matches(r) {
+			reviews = append(reviews, r)
+		}
+	}
+	sort.SliceStable(reviews, func(i, j int) bool {
+		return reviews[i].Time.Before(reviews[j].Time)
+	})
+	return reviews, nil
+}
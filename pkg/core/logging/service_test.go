@@ -0,0 +1,120 @@
+package logging
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+)
+
+var errLogUnreadable error = errors.New("log unreadable")
+
+var now = time.Date(2022, time.November, 1, 12, 0, 0, 0, time.UTC)
+
+type repositoryStub struct {
+	reviews []Review
+	err     error
+}
+
+func newRepositoryStubWithReviews() *repositoryStub {
+	return &repositoryStub{
+		reviews: []Review{
+			{Card: "Group.SubGroup.Subject1", Time: now, Grade: reviewing.Good},
+			{Card: "Group.Subject1", Time: now.AddDate(0, 0, -1), Grade: reviewing.Again},
+			{Card: "Group.Subject1", Time: now.AddDate(0, 0, -2), Grade: reviewing.Good},
+			{Card: "Group.Subject10", Time: now.AddDate(0, 0, -3), Grade: reviewing.Easy},
+			{Card: "Other.Subject1", Time: now.AddDate(0, 0, -4), Grade: reviewing.Hard},
+		},
+	}
+}
+
+func (r *repositoryStub) GetReviews() ([]Review, error) {
+	return r.reviews, r.err
+}
+
+func TestGetReviews(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   Query
+		want    []string
+		wantErr error
+	}{
+		{
+			name:  "All",
+			query: Query{},
+			want: []string{
+				"Other.Subject1", "Group.Subject10", "Group.Subject1",
+				"Group.Subject1", "Group.SubGroup.Subject1",
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Card",
+			query:   Query{Card: "Group.Subject1"},
+			want:    []string{"Group.Subject1", "Group.Subject1"},
+			wantErr: nil,
+		},
+		{
+			name:  "Group",
+			query: Query{Group: "Group"},
+			want: []string{
+				"Group.Subject10", "Group.Subject1", "Group.Subject1", "Group.SubGroup.Subject1",
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Sub Group",
+			query:   Query{Group: "Group.SubGroup"},
+			want:    []string{"Group.SubGroup.Subject1"},
+			wantErr: nil,
+		},
+		{
+			name:    "Date Range",
+			query:   Query{From: now.AddDate(0, 0, -3), To: now},
+			want:    []string{"Group.Subject10", "Group.Subject1", "Group.Subject1"},
+			wantErr: nil,
+		},
+		{
+			name:    "Group And Date Range",
+			query:   Query{Group: "Group", From: now.AddDate(0, 0, -1)},
+			want:    []string{"Group.Subject1", "Group.SubGroup.Subject1"},
+			wantErr: nil,
+		},
+		{
+			name:    "No Match",
+			query:   Query{Card: "Group.NotFound"},
+			want:    []string{},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ls := New(newRepositoryStubWithReviews())
+			reviews, err := ls.GetReviews(tt.query)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			got := []string{}
+			for _, r := range reviews {
+				got = append(got, r.Card)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect reviews. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetReviewsError(t *testing.T) {
+	ls := New(&repositoryStub{err: errLogUnreadable})
+	_, err := ls.GetReviews(Query{})
+
+	if err != errLogUnreadable {
+		t.Errorf("Incorrect error. Want %v, got %v", errLogUnreadable, err)
+	}
+}
@@ -0,0 +1,86 @@
+package moving
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/storage"
+)
+
+var (
+	ErrEmptyPath  error = errors.New("path is empty")
+	ErrSamePath   error = errors.New("source and destination are the same")
+	ErrIntoItself error = errors.New("group cannot be moved into itself")
+)
+
+// Service moves cards and groups. Paths are dotted, like a card's title.
+// Cards keep their ID, creation time and schedules when moved.
+type Service interface {
+	Move(string, string) error
+}
+
+type Repository interface {
+	MoveCard(string, string) error
+	MoveGroup(string, string) error
+}
+
+type service struct {
+	g getting.Service
+	r Repository
+}
+
+func New(g getting.Service, r Repository) *service {
+	return &service{g, r}
+}
+
+// Move moves the card at the source path when there is one, or else the
+// group and everything under it. Like mv, moving onto an existing group
+// moves the source into that group.
+func (s *service) Move(src, dst string) error {
+	if src == "" || dst == "" {
+		return ErrEmptyPath
+	}
+	if s.isGroup(dst) {
+		_, name := storage.SplitPath(src)
+		dst = storage.JoinPath(dst, name)
+	}
+	if src == dst {
+		return ErrSamePath
+	}
+
+	if s.isCard(src) {
+		return s.r.MoveCard(src, dst)
+	}
+	if strings.HasPrefix(dst, src+".") {
+		return ErrIntoItself
+	}
+	return s.r.MoveGroup(src, dst)
+}
+
+func (s *service) isCard(p string) bool {
+	g, _ := storage.SplitPath(p)
+	cards, err := s.g.GetCards(g, getting.All)
+	if err != nil {
+		return false
+	}
+	for _, c := range cards {
+		if c.Title == p {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *service) isGroup(p string) bool {
+	cards, err := s.g.GetAllCards(p, getting.All)
+	if err != nil {
+		return false
+	}
+	for _, c := range cards {
+		if strings.HasPrefix(c.Title, p+".") {
+			return true
+		}
+	}
+	return false
+}
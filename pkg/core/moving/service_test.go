@@ -0,0 +1,230 @@
+package moving
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/storage"
+)
+
+var (
+	errCardNotFound  error = errors.New("card not found")
+	errGroupNotFound error = errors.New("group not found")
+)
+
+// storeStub is both the getting service and the repository, so a move is
+// seen by the next lookup.
+type storeStub struct {
+	titles []string
+}
+
+func newStoreStubWithCards() *storeStub {
+	return &storeStub{
+		titles: []string{
+			"Subject1",
+			"Group.Subject1",
+			"Group.Subject2",
+			"Group.SubGroup.Subject1",
+			"Other.Subject1",
+		},
+	}
+}
+
+func (s *storeStub) GetCards(g string, _ getting.Filter) ([]getting.Card, error) {
+	var cards []getting.Card
+	for _, t := range s.titles {
+		if p, _ := storage.SplitPath(t); p == g {
+			cards = append(cards, getting.Card{Title: t})
+		}
+	}
+	if len(cards) == 0 {
+		return cards, errGroupNotFound
+	}
+	return cards, nil
+}
+
+func (s *storeStub) GetAllCards(g string, _ getting.Filter) ([]getting.Card, error) {
+	var cards []getting.Card
+	for _, t := range s.titles {
+		if strings.HasPrefix(t, g) {
+			cards = append(cards, getting.Card{Title: t})
+		}
+	}
+	if len(cards) == 0 {
+		return cards, errGroupNotFound
+	}
+	return cards, nil
+}
+
+func (s *storeStub) GetCardsInBox(string, int, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (s *storeStub) GetLeeches(string) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (s *storeStub) MoveCard(src, dst string) error {
+	for i, t := range s.titles {
+		if t == src {
+			s.titles[i] = dst
+			return nil
+		}
+	}
+	return errCardNotFound
+}
+
+func (s *storeStub) MoveGroup(src, dst string) error {
+	found := false
+	for i, t := range s.titles {
+		if strings.HasPrefix(t, src+".") {
+			s.titles[i] = dst + strings.TrimPrefix(t, src)
+			found = true
+		}
+	}
+	if !found {
+		return errGroupNotFound
+	}
+	return nil
+}
+
+func TestMove(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		dst     string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "Rename Card",
+			src:  "Group.Subject1",
+			dst:  "Group.Renamed",
+			want: []string{
+				"Group.Renamed",
+				"Group.SubGroup.Subject1",
+				"Group.Subject2",
+				"Other.Subject1",
+				"Subject1",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Card Into Group",
+			src:  "Group.Subject2",
+			dst:  "Other",
+			want: []string{
+				"Group.SubGroup.Subject1",
+				"Group.Subject1",
+				"Other.Subject1",
+				"Other.Subject2",
+				"Subject1",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Root Card",
+			src:  "Subject1",
+			dst:  "Group.SubGroup.Root",
+			want: []string{
+				"Group.SubGroup.Root",
+				"Group.SubGroup.Subject1",
+				"Group.Subject1",
+				"Group.Subject2",
+				"Other.Subject1",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Rename Group",
+			src:  "Group",
+			dst:  "Renamed",
+			want: []string{
+				"Other.Subject1",
+				"Renamed.SubGroup.Subject1",
+				"Renamed.Subject1",
+				"Renamed.Subject2",
+				"Subject1",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Group Into Group",
+			src:  "Group.SubGroup",
+			dst:  "Other",
+			want: []string{
+				"Group.Subject1",
+				"Group.Subject2",
+				"Other.SubGroup.Subject1",
+				"Other.Subject1",
+				"Subject1",
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Group Into Itself",
+			src:     "Group",
+			dst:     "Group.SubGroup.Nested",
+			want:    nil,
+			wantErr: ErrIntoItself,
+		},
+		{
+			name:    "Into Own Group",
+			src:     "Group.Subject1",
+			dst:     "Group",
+			want:    nil,
+			wantErr: ErrSamePath,
+		},
+		{
+			name:    "Same Path",
+			src:     "Group.Subject1",
+			dst:     "Group.Subject1",
+			want:    nil,
+			wantErr: ErrSamePath,
+		},
+		{
+			name:    "Empty Source",
+			src:     "",
+			dst:     "Group",
+			want:    nil,
+			wantErr: ErrEmptyPath,
+		},
+		{
+			name:    "Empty Destination",
+			src:     "Group",
+			dst:     "",
+			want:    nil,
+			wantErr: ErrEmptyPath,
+		},
+		{
+			name:    "Not Found",
+			src:     "NotFound",
+			dst:     "Group.Found",
+			want:    nil,
+			wantErr: errGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newStoreStubWithCards()
+			err := New(store, store).Move(tt.src, tt.dst)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			sort.Strings(store.titles)
+			if !reflect.DeepEqual(tt.want, store.titles) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, store.titles)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Package note fills the templates of a note type, written with {{Field}}
+// placeholders, with the fields of a note. A back template may also use
+// {{FrontSide}} to repeat its rendered front.
+package note
+
+import (
+	"regexp"
+	"strings"
+)
+
+// FrontSide is the placeholder a back template uses for its rendered front.
+const FrontSide = "FrontSide"
+
+var placeholderRe = regexp.MustCompile(`\{\{\s*([^{}:]+?)\s*\}\}`)
+
+// ValidField reports whether name can be used as a field's name. Field
+// names cannot be empty, contain braces or colons, or be FrontSide.
+func ValidField(name string) bool {
+	return name != "" && name == strings.TrimSpace(name) && name != FrontSide &&
+		!strings.ContainsAny(name, "{}:")
+}
+
+// Fields returns the fields used by the template, in the order they first
+// appear.
+func Fields(tmpl string) []string {
+	seen := map[string]bool{}
+	var fields []string
+	for _, m := range placeholderRe.FindAllStringSubmatch(tmpl, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			fields = append(fields, m[1])
+		}
+	}
+	return fields
+}
+
+// Render fills the template's placeholders with the fields. Fields that are
+// missing render as nothing.
+func Render(tmpl string, fields map[string]string) string {
+	return placeholderRe.ReplaceAllStringFunc(tmpl, func(p string) string {
+		return fields[placeholderRe.FindStringSubmatch(p)[1]]
+	})
+}
+
+// RenderBack fills the back template, with FrontSide standing for the
+// rendered front template.
+func RenderBack(front, back string, fields map[string]string) string {
+	withFront := map[string]string{FrontSide: Render(front, fields)}
+	for k, v := range fields {
+		withFront[k] = v
+	}
+	return Render(back, withFront)
+}
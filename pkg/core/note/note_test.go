@@ -0,0 +1,85 @@
+package note
+
+import (
+	"reflect"
+	"testing"
+)
+
+var fields = map[string]string{
+	"Word":    "猫",
+	"Reading": "ねこ",
+	"Meaning": "cat",
+}
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		want []string
+	}{
+		{"Normal", "{{Word}} ({{Reading}})", []string{"Word", "Reading"}},
+		{"Repeated", "{{Word}} {{ Word }}", []string{"Word"}},
+		{"Cloze Ignored", "{{c1::Word}}", nil},
+		{"None", "Word", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fields(tt.tmpl); !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect fields. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{"Normal", "{{Word}} ({{Reading}})", "猫 (ねこ)"},
+		{"Spaces", "{{ Meaning }}", "cat"},
+		{"Missing Field", "{{Word}}{{Example}}", "猫"},
+		{"Cloze Untouched", "{{c1::x}}", "{{c1::x}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.tmpl, fields); got != tt.want {
+				t.Errorf("Incorrect text. Want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderBack(t *testing.T) {
+	want := "猫 = cat"
+	if got := RenderBack("{{Word}}", "{{FrontSide}} = {{Meaning}}", fields); got != want {
+		t.Errorf("Incorrect text. Want %q, got %q", want, got)
+	}
+}
+
+func TestValidField(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		want  bool
+	}{
+		{"Normal", "Word", true},
+		{"Spaces Inside", "Example Sentence", true},
+		{"Empty", "", false},
+		{"Padded", " Word", false},
+		{"Braces", "{Word}", false},
+		{"Colon", "c1:Word", false},
+		{"Front Side", "FrontSide", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidField(tt.field); got != tt.want {
+				t.Errorf("Incorrect validity. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
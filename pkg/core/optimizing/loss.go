@@ -0,0 +1,95 @@
+package optimizing
+
+import (
+	"math"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/logging"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+)
+
+// minProbability keeps the log-loss finite when a prediction is certain.
+const minProbability = 1e-6
+
+// bounds keep each FSRS weight where the model stays meaningful.
+var bounds = [][2]float64{
+	{0.1, 100}, {0.1, 100}, {0.1, 100}, {0.1, 100},
+	{1, 10}, {0.1, 5}, {0.1, 5}, {0, 0.5},
+	{0, 3}, {0.1, 0.8}, {0.01, 2.5},
+	{0.5, 5}, {0.01, 0.2}, {0.01, 0.9}, {0.01, 2},
+	{0, 1}, {1, 4},
+}
+
+// history is the reviews of a single face, oldest first.
+type history []logging.Review
+
+// histories splits the reviews, oldest first, by face.
+func histories(reviews []logging.Review) []history {
+	var hs []history
+	index := map[string]int{}
+	for _, r := range reviews {
+		key := r.Card + "/" + r.Face
+		i, ok := index[key]
+		if !ok {
+			i = len(hs)
+			index[key] = i
+			hs = append(hs, nil)
+		}
+		hs[i] = append(hs[i], r)
+	}
+	return hs
+}
+
+// predictions counts the reviews that the weights' recall is predicted
+// for, which are those made at least a day after the face's last review.
+func predictions(hs []history) int {
+	n := 0
+	for _, h := range hs {
+		for i := 1; i < len(h); i++ {
+			if elapsed(h[i-1].Time, h[i].Time) >= 1 {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// loss replays each face's reviews through FSRS with the weights and
+// returns the mean log-loss of the predicted recall against whether the
+// face was recalled.
+func loss(w []float64, hs []history) float64 {
+	sched, err := reviewing.NewFSRS(w, 0)
+	if err != nil {
+		return math.Inf(1)
+	}
+
+	total := 0.0
+	n := 0
+	for _, h := range hs {
+		var st reviewing.State
+		for i, r := range h {
+			if i > 0 {
+				if t := elapsed(st.Reviewed, r.Time); t >= 1 {
+					p := reviewing.Retrievability(t, st.Stability)
+					p = math.Min(math.Max(p, minProbability), 1-minProbability)
+					if r.Grade == reviewing.Again {
+						total -= math.Log(1 - p)
+					} else {
+						total -= math.Log(p)
+					}
+					n++
+				}
+			}
+			st, _ = sched.Schedule(st, r.Grade, r.Time)
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return total / float64(n)
+}
+
+// elapsed counts whole days between two reviews, as FSRS does.
+func elapsed(from, to time.Time) float64 {
+	return math.Max(0, math.Floor(to.Sub(from).Hours()/24))
+}
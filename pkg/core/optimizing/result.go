@@ -0,0 +1,20 @@
+package optimizing
+
+type Result struct {
+	Weights []float64
+	// Reviews is the number of reviews the weights were fitted to.
+	Reviews int
+	// DefaultLoss and Loss are the mean log-loss of the default and fitted
+	// weights. Lower is better.
+	DefaultLoss float64
+	Loss        float64
+}
+
+// Improvement returns how much lower the fitted weights' loss is than the
+// defaults', as a share of the defaults'.
+func (r Result) Improvement() float64 {
+	if r.DefaultLoss == 0 {
+		return 0
+	}
+	return (r.DefaultLoss - r.Loss) / r.DefaultLoss
+}
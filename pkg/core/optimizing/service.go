@@ -0,0 +1,141 @@
+package optimizing
+
+import (
+	"errors"
+	"math"
+
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
+	"github.com/jmcveigh55/flash/pkg/core/logging"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+)
+
+// MinReviews is the fewest predictable reviews worth fitting weights to.
+const MinReviews = 20
+
+// Adam gradient descent settings. Each step moves a weight by about
+// learningRate of its bounded range.
+const (
+	iterations   = 300
+	learningRate = 0.005
+	beta1        = 0.9
+	beta2        = 0.999
+	epsilon      = 1e-8
+	// delta is the step, relative to a weight's range, used to estimate
+	// the gradient by finite differences.
+	delta = 1e-5
+)
+
+var (
+	ErrNotEnoughReviews error = errors.New("not enough reviews to optimize from")
+	ErrNotFSRS          error = errors.New("group does not use the fsrs scheduler")
+)
+
+type Service interface {
+	Optimize(string) (*Result, error)
+	SaveWeights(string, []float64) error
+}
+
+type service struct {
+	l logging.Service
+	c configuring.Service
+}
+
+func New(l logging.Service, c configuring.Service) *service {
+	return &service{l, c}
+}
+
+// Optimize fits the FSRS weights to the review log of the cards under the
+// group, starting from the defaults, by minimising the log-loss of the
+// recall they predict.
+func (s *service) Optimize(g string) (*Result, error) {
+	reviews, err := s.l.GetReviews(logging.Query{Group: g})
+	if err != nil {
+		return nil, err
+	}
+	hs := histories(reviews)
+	n := predictions(hs)
+	if n < MinReviews {
+		return nil, ErrNotEnoughReviews
+	}
+
+	defaults := reviewing.DefaultWeights()
+	w := fit(defaults, hs)
+	return &Result{
+		Weights:     w,
+		Reviews:     n,
+		DefaultLoss: loss(defaults, hs),
+		Loss:        loss(w, hs),
+	}, nil
+}
+
+// SaveWeights sets the group's FSRS weights, leaving its other settings
+// alone. The group must use FSRS, set on it or inherited, as no other
+// scheduler reads the weights.
+func (s *service) SaveWeights(g string, w []float64) error {
+	resolved, err := configuring.Resolve(s.c.GetConfig, g)
+	if err != nil {
+		return err
+	}
+	if resolved.Scheduler != reviewing.FSRS {
+		return ErrNotFSRS
+	}
+
+	config, err := s.c.GetConfig(g)
+	if err != nil {
+		return err
+	}
+	config.Weights = w
+	return s.c.SetConfig(g, config)
+}
+
+// fit runs Adam from the initial weights, keeping them within their bounds,
+// and returns the best weights found rounded as the defaults are.
+func fit(initial []float64, hs []history) []float64 {
+	w := append([]float64{}, initial...)
+	best := append([]float64{}, initial...)
+	bestLoss := loss(w, hs)
+
+	m := make([]float64, len(w))
+	v := make([]float64, len(w))
+	for t := 1; t <= iterations; t++ {
+		grad := gradient(w, hs)
+		for i := range w {
+			span := bounds[i][1] - bounds[i][0]
+			m[i] = beta1*m[i] + (1-beta1)*grad[i]
+			v[i] = beta2*v[i] + (1-beta2)*grad[i]*grad[i]
+			mHat := m[i] / (1 - math.Pow(beta1, float64(t)))
+			vHat := v[i] / (1 - math.Pow(beta2, float64(t)))
+			w[i] = clamp(w[i]-learningRate*span*mHat/(math.Sqrt(vHat)+epsilon), i)
+		}
+
+		if l := loss(w, hs); l < bestLoss {
+			bestLoss = l
+			copy(best, w)
+		}
+	}
+
+	for i := range best {
+		best[i] = math.Round(best[i]*1e4) / 1e4
+	}
+	return best
+}
+
+// gradient estimates the gradient of the loss by central differences.
+func gradient(w []float64, hs []history) []float64 {
+	grad := make([]float64, len(w))
+	x := append([]float64{}, w...)
+	for i := range w {
+		h := delta * (bounds[i][1] - bounds[i][0])
+		x[i] = clamp(w[i]+h, i)
+		up := loss(x, hs)
+		x[i] = clamp(w[i]-h, i)
+		down := loss(x, hs)
+		x[i] = w[i]
+		grad[i] = (up - down) / (2 * h)
+	}
+	return grad
+}
+
+func clamp(v float64, i int) float64 {
+	return math.Min(math.Max(v, bounds[i][0]), bounds[i][1])
+}
@@ -0,0 +1,227 @@
+package optimizing
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/logging"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+)
+
+var errGroupNotFound error = errors.New("group not found")
+
+var start = time.Date(2022, time.November, 1, 12, 0, 0, 0, time.UTC)
+
+type loggingStub struct {
+	reviews []logging.Review
+}
+
+func (l *loggingStub) GetReviews(q logging.Query) ([]logging.Review, error) {
+	if q.Group != "Group" {
+		return nil, errGroupNotFound
+	}
+	return l.reviews, nil
+}
+
+type configuringStub struct {
+	configs map[string]configuring.Config
+}
+
+func (c *configuringStub) GetConfig(g string) (configuring.Config, error) {
+	return c.configs[g], nil
+}
+
+func (c *configuringStub) SetConfig(g string, config configuring.Config) error {
+	c.configs[g] = config
+	return nil
+}
+
+// forgetfulLog is the log of faces that are recalled a day after being
+// seen but forgotten after any longer gap, which the default weights
+// predict badly.
+func forgetfulLog(faces int) []logging.Review {
+	gaps := []int{0, 1, 3, 1, 4, 1, 5}
+	var reviews []logging.Review
+	for f := 0; f < faces; f++ {
+		day := 0
+		for _, gap := range gaps {
+			day += gap
+			grade := reviewing.Good
+			if gap > 1 {
+				grade = reviewing.Again
+			}
+			reviews = append(reviews, logging.Review{
+				Card:  fmt.Sprintf("Group.Card%d", f),
+				Face:  getting.Forward,
+				Time:  start.AddDate(0, 0, day),
+				Grade: grade,
+			})
+		}
+	}
+	return reviews
+}
+
+func TestOptimize(t *testing.T) {
+	tests := []struct {
+		name        string
+		group       string
+		reviews     []logging.Review
+		wantReviews int
+		wantErr     error
+	}{
+		{
+			name:        "Normal",
+			group:       "Group",
+			reviews:     forgetfulLog(5),
+			wantReviews: 30,
+			wantErr:     nil,
+		},
+		{
+			name:        "Not Enough Reviews",
+			group:       "Group",
+			reviews:     forgetfulLog(3),
+			wantReviews: 0,
+			wantErr:     ErrNotEnoughReviews,
+		},
+		{
+			name:        "Group Not Found",
+			group:       "NotFound",
+			wantReviews: 0,
+			wantErr:     errGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops := New(&loggingStub{tt.reviews}, &configuringStub{})
+			got, err := ops.Optimize(tt.group)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.Reviews != tt.wantReviews {
+				t.Errorf("Incorrect reviews. Want %v, got %v", tt.wantReviews, got.Reviews)
+			}
+			if got.Loss >= got.DefaultLoss {
+				t.Errorf("Incorrect loss. Want less than %v, got %v", got.DefaultLoss, got.Loss)
+			}
+			if len(got.Weights) != len(bounds) {
+				t.Fatalf("Incorrect weight count. Want %v, got %v", len(bounds), len(got.Weights))
+			}
+			for i, w := range got.Weights {
+				if w < bounds[i][0] || w > bounds[i][1] {
+					t.Errorf("Incorrect weight %d. Want within %v, got %v", i, bounds[i], w)
+				}
+			}
+		})
+	}
+}
+
+func TestLoss(t *testing.T) {
+	reviews := []logging.Review{
+		{Card: "Group.Card", Face: getting.Forward, Time: start, Grade: reviewing.Good},
+		{Card: "Group.Card", Face: getting.Forward, Time: start.Add(time.Hour), Grade: reviewing.Again},
+		{Card: "Group.Other", Face: getting.Forward, Time: start, Grade: reviewing.Good},
+	}
+
+	hs := histories(reviews)
+	if len(hs) != 2 {
+		t.Fatalf("Incorrect histories. Want 2, got %v", len(hs))
+	}
+	if got := predictions(hs); got != 0 {
+		t.Errorf("Incorrect predictions. Want 0, got %v", got)
+	}
+	if got := loss(reviewing.DefaultWeights(), hs); got != 0 {
+		t.Errorf("Incorrect loss. Want 0, got %v", got)
+	}
+}
+
+func TestSaveWeights(t *testing.T) {
+	w := reviewing.DefaultWeights()
+	tests := []struct {
+		name    string
+		group   string
+		configs map[string]configuring.Config
+		want    configuring.Config
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			configs: map[string]configuring.Config{
+				"Group": {Scheduler: "fsrs", Retention: 0.85},
+			},
+			want:    configuring.Config{Scheduler: "fsrs", Weights: w, Retention: 0.85},
+			wantErr: nil,
+		},
+		{
+			name:  "Inherited",
+			group: "Group.SubGroup",
+			configs: map[string]configuring.Config{
+				"Group": {Scheduler: "fsrs"},
+			},
+			want:    configuring.Config{Weights: w},
+			wantErr: nil,
+		},
+		{
+			name:    "Default Scheduler",
+			group:   "Group",
+			configs: map[string]configuring.Config{},
+			want:    configuring.Config{},
+			wantErr: ErrNotFSRS,
+		},
+		{
+			name:  "Overridden",
+			group: "Group.SubGroup",
+			configs: map[string]configuring.Config{
+				"Group":          {Scheduler: "fsrs"},
+				"Group.SubGroup": {Scheduler: "leitner"},
+			},
+			want:    configuring.Config{Scheduler: "leitner"},
+			wantErr: ErrNotFSRS,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &configuringStub{tt.configs}
+			err := New(&loggingStub{}, c).SaveWeights(tt.group, w)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if got := c.configs[tt.group]; !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect config. Want %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestImprovement(t *testing.T) {
+	tests := []struct {
+		name   string
+		result Result
+		want   float64
+	}{
+		{"Normal", Result{DefaultLoss: 0.5, Loss: 0.4}, 0.2},
+		{"No Loss", Result{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.Improvement(); got < tt.want-1e-9 || got > tt.want+1e-9 {
+				t.Errorf("Incorrect improvement. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package quizzing
+
+import "errors"
+
+var (
+	ErrQuizFinished  error = errors.New("quiz has no questions left")
+	ErrInvalidOption error = errors.New("invalid option")
+)
+
+// Question asks which of the options is the description of the card with
+// the title.
+type Question struct {
+	Title   string
+	Options []string
+	// Answer is the index of the correct option.
+	Answer int
+}
+
+type Score struct {
+	Correct int
+	Total   int
+	// Skipped counts the cards with no other descriptions to draw
+	// distractors from.
+	Skipped int
+}
+
+// Quiz walks through a list of multiple choice questions, keeping score.
+type Quiz struct {
+	questions []Question
+	score     Score
+}
+
+// Next returns the current question and whether there is one.
+func (q *Quiz) Next() (Question, bool) {
+	if len(q.questions) == 0 {
+		return Question{}, false
+	}
+	return q.questions[0], true
+}
+
+// Remaining returns the number of questions left in the quiz.
+func (q *Quiz) Remaining() int {
+	return len(q.questions)
+}
+
+// Answer chooses an option for the current question, reporting whether it
+// was correct, and moves on to the next.
+func (q *Quiz) Answer(option int) (bool, error) {
+	question, ok := q.Next()
+	if !ok {
+		return false, ErrQuizFinished
+	}
+	if option < 0 || option >= len(question.Options) {
+		return false, ErrInvalidOption
+	}
+
+	q.questions = q.questions[1:]
+	q.score.Total++
+	correct := option == question.Answer
+	if correct {
+		q.score.Correct++
+	}
+	return correct, nil
+}
+
+// Score returns the results of the quiz so far.
+func (q *Quiz) Score() Score {
+	return q.score
+}
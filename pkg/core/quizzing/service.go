@@ -0,0 +1,132 @@
+package quizzing
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/storage"
+)
+
+const DefaultChoices = 4
+
+var (
+	ErrInvalidChoices   error = errors.New("a question needs at least two choices")
+	ErrNotEnoughChoices error = errors.New("not enough cards to draw distractors from")
+)
+
+type Service interface {
+	Quiz(string, int) (*Quiz, error)
+}
+
+type service struct {
+	g     getting.Service
+	clock storage.Clock
+}
+
+func New(g getting.Service, c storage.Clock) *service {
+	return &service{g, c}
+}
+
+// Quiz builds a multiple choice question with up to the number of choices
+// for each card under the group, in random order. Distractors are the
+// descriptions of other cards in the card's group, then in each parent group
+// in turn. Cards without any distractors are skipped. Cloze and note cards
+// have no description to choose and are left out.
+func (s *service) Quiz(g string, choices int) (*Quiz, error) {
+	if choices < 2 {
+		return nil, ErrInvalidChoices
+	}
+
+	cards, err := s.g.GetAllCards(g, getting.Unsuspended)
+	if err != nil {
+		return nil, err
+	}
+
+	rnd := rand.New(rand.NewSource(s.clock.Now().UnixNano()))
+	rnd.Shuffle(len(cards), func(i, j int) {
+		cards[i], cards[j] = cards[j], cards[i]
+	})
+
+	q := &Quiz{}
+	pools := map[string][]string{}
+	for _, c := range cards {
+		if c.Cloze || c.Note != nil {
+			continue
+		}
+		distractors := s.distractors(c, choices-1, pools, rnd)
+		if len(distractors) == 0 {
+			q.score.Skipped++
+			continue
+		}
+
+		options := append(distractors, c.Desc)
+		rnd.Shuffle(len(options), func(i, j int) {
+			options[i], options[j] = options[j], options[i]
+		})
+		answer := 0
+		for i, o := range options {
+			if o == c.Desc {
+				answer = i
+			}
+		}
+		q.questions = append(q.questions, Question{c.Title, options, answer})
+	}
+
+	if len(q.questions) == 0 {
+		return nil, ErrNotEnoughChoices
+	}
+	return q, nil
+}
+
+// distractors draws up to n descriptions other than the card's own, from
+// the closest groups first.
+func (s *service) distractors(c getting.Card, n int, pools map[string][]string, rnd *rand.Rand) []string {
+	seen := map[string]bool{c.Desc: true}
+	var distractors []string
+
+	group, _ := storage.SplitPath(c.Title)
+	for {
+		pool := s.pool(group, pools)
+		var found []string
+		for _, d := range pool {
+			if !seen[d] {
+				seen[d] = true
+				found = append(found, d)
+			}
+		}
+		rnd.Shuffle(len(found), func(i, j int) {
+			found[i], found[j] = found[j], found[i]
+		})
+		distractors = append(distractors, found...)
+
+		if len(distractors) >= n {
+			return distractors[:n]
+		}
+		if group == "" {
+			return distractors
+		}
+		group, _ = storage.SplitPath(group)
+	}
+}
+
+// pool returns the descriptions of every card under the group, suspended or
+// not, caching them for the rest of the quiz. Cloze and note cards are left
+// out.
+func (s *service) pool(g string, pools map[string][]string) []string {
+	if pool, ok := pools[g]; ok {
+		return pool
+	}
+
+	pool := []string{}
+	cards, err := s.g.GetAllCards(g, getting.All)
+	if err == nil {
+		for _, c := range cards {
+			if !c.Cloze && c.Note == nil {
+				pool = append(pool, c.Desc)
+			}
+		}
+	}
+	pools[g] = pool
+	return pool
+}
@@ -0,0 +1,249 @@
+package quizzing
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+)
+
+var errGroupNotFound error = errors.New("group not found")
+
+var now = time.Date(2022, time.November, 1, 12, 0, 0, 0, time.UTC)
+
+type clockStub struct {
+	t time.Time
+}
+
+func (c *clockStub) Now() time.Time {
+	return c.t
+}
+
+type gettingStub struct {
+	cards []getting.Card
+}
+
+func newGettingStubWithCards() *gettingStub {
+	return &gettingStub{
+		cards: []getting.Card{
+			{Title: "Group.Subject1", Desc: "Value1"},
+			{Title: "Group.Subject2", Desc: "Value2"},
+			{Title: "Group.SubGroup.Subject1", Desc: "Value3"},
+			{Title: "Group.SubGroup.Subject2", Desc: "Value4"},
+			{Title: "Group.SubGroup.Subject3", Desc: "Value4"},
+			{Title: "Other.Subject1", Desc: "Value5"},
+			{Title: "Group.SubGroup.Cloze", Desc: "{{c1::Value6}}", Cloze: true},
+			{
+				Title: "Group.SubGroup.Note",
+				Note: &getting.Note{
+					Type:      "Basic",
+					Fields:    map[string]string{"Front": "Subject4", "Back": "Value7"},
+					Templates: []getting.Template{{Name: "Card 1", Front: "{{Front}}", Back: "{{Back}}"}},
+				},
+			},
+		},
+	}
+}
+
+func (g *gettingStub) GetCards(string, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (g *gettingStub) GetAllCards(group string, _ getting.Filter) ([]getting.Card, error) {
+	cards := []getting.Card{}
+	for _, c := range g.cards {
+		if group == "" || strings.HasPrefix(c.Title, group+".") {
+			cards = append(cards, c)
+		}
+	}
+	if len(cards) == 0 {
+		return cards, errGroupNotFound
+	}
+	return cards, nil
+}
+
+func (g *gettingStub) GetCardsInBox(string, int, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (g *gettingStub) GetLeeches(string) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func TestQuiz(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		choices int
+		want    map[string][][]string
+		wantErr error
+	}{
+		{
+			name:    "Same Group",
+			group:   "Group.SubGroup",
+			choices: 2,
+			want: map[string][][]string{
+				"Group.SubGroup.Subject1": {{"Value3", "Value4"}},
+				"Group.SubGroup.Subject2": {{"Value3", "Value4"}},
+				"Group.SubGroup.Subject3": {{"Value3", "Value4"}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Parent Group",
+			group:   "Group.SubGroup",
+			choices: 3,
+			want: map[string][][]string{
+				"Group.SubGroup.Subject1": {
+					{"Value1", "Value3", "Value4"},
+					{"Value2", "Value3", "Value4"},
+				},
+				"Group.SubGroup.Subject2": {
+					{"Value1", "Value3", "Value4"},
+					{"Value2", "Value3", "Value4"},
+				},
+				"Group.SubGroup.Subject3": {
+					{"Value1", "Value3", "Value4"},
+					{"Value2", "Value3", "Value4"},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Cloze And Note Cards Left Out",
+			group:   "Group.SubGroup",
+			choices: 4,
+			want: map[string][][]string{
+				"Group.SubGroup.Subject1": {{"Value1", "Value2", "Value3", "Value4"}},
+				"Group.SubGroup.Subject2": {{"Value1", "Value2", "Value3", "Value4"}},
+				"Group.SubGroup.Subject3": {{"Value1", "Value2", "Value3", "Value4"}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Fewer Choices",
+			group:   "Group",
+			choices: 8,
+			want: map[string][][]string{
+				"Group.Subject1":          {{"Value1", "Value2", "Value3", "Value4", "Value5"}},
+				"Group.Subject2":          {{"Value1", "Value2", "Value3", "Value4", "Value5"}},
+				"Group.SubGroup.Subject1": {{"Value1", "Value2", "Value3", "Value4", "Value5"}},
+				"Group.SubGroup.Subject2": {{"Value1", "Value2", "Value3", "Value4", "Value5"}},
+				"Group.SubGroup.Subject3": {{"Value1", "Value2", "Value3", "Value4", "Value5"}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Invalid Choices",
+			group:   "Group",
+			choices: 1,
+			want:    map[string][][]string{},
+			wantErr: ErrInvalidChoices,
+		},
+		{
+			name:    "Group Not Found",
+			group:   "NotFound",
+			choices: 4,
+			want:    map[string][][]string{},
+			wantErr: errGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qs := New(newGettingStubWithCards(), &clockStub{now})
+			quiz, err := qs.Quiz(tt.group, tt.choices)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			got := map[string][]string{}
+			for quiz != nil {
+				q, ok := quiz.Next()
+				if !ok {
+					break
+				}
+				options := append([]string{}, q.Options...)
+				sort.Strings(options)
+				got[q.Title] = options
+				quiz.Answer(q.Answer)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Errorf("Incorrect questions. Want %v, got %v", tt.want, got)
+			}
+			for title, options := range got {
+				if !containsOptions(tt.want[title], options) {
+					t.Errorf("Incorrect options for %s. Want one of %v, got %v", title, tt.want[title], options)
+				}
+			}
+		})
+	}
+}
+
+func containsOptions(want [][]string, got []string) bool {
+	for _, w := range want {
+		if reflect.DeepEqual(w, got) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestQuizNotEnoughChoices(t *testing.T) {
+	g := &gettingStub{
+		cards: []getting.Card{
+			{Title: "Group.Subject1", Desc: "Value1"},
+			{Title: "Group.Subject2", Desc: "Value1"},
+		},
+	}
+	qs := New(g, &clockStub{now})
+	_, err := qs.Quiz("Group", DefaultChoices)
+
+	if err != ErrNotEnoughChoices {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrNotEnoughChoices, err)
+	}
+}
+
+func TestQuizAnswer(t *testing.T) {
+	qs := New(newGettingStubWithCards(), &clockStub{now})
+	quiz, err := qs.Quiz("Group.SubGroup", DefaultChoices)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	q, _ := quiz.Next()
+	if _, err := quiz.Answer(len(q.Options)); err != ErrInvalidOption {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrInvalidOption, err)
+	}
+
+	wantCorrect := []bool{true, false, true}
+	for _, want := range wantCorrect {
+		q, _ := quiz.Next()
+		option := q.Answer
+		if !want {
+			option = (q.Answer + 1) % len(q.Options)
+		}
+		correct, err := quiz.Answer(option)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if correct != want {
+			t.Errorf("Incorrect result. Want %v, got %v", want, correct)
+		}
+	}
+
+	if _, err := quiz.Answer(0); err != ErrQuizFinished {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrQuizFinished, err)
+	}
+
+	wantScore := Score{Correct: 2, Total: 3}
+	if got := quiz.Score(); !reflect.DeepEqual(wantScore, got) {
+		t.Errorf("Incorrect score. Want %v, got %v", wantScore, got)
+	}
+}
@@ -0,0 +1,62 @@
+package reporting
+
+import (
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+)
+
+// Plan is the daily workload needed to study every new face and review
+// every face TargetReviews times before a group's target date, next to the
+// group's daily limits.
+type Plan struct {
+	Target   time.Time
+	DaysLeft int
+	// NewPerDay and ReviewsPerDay are the new faces and reviews needed
+	// each day.
+	NewPerDay     int
+	ReviewsPerDay int
+	// NewLimit and ReviewsLimit are the group's daily limits, with zero or
+	// less meaning there is none.
+	NewLimit     int
+	ReviewsLimit int
+}
+
+// NewOverLimit reports whether the new card limit is too low to keep to
+// the plan.
+func (p Plan) NewOverLimit() bool {
+	return p.NewLimit > 0 && p.NewPerDay > p.NewLimit
+}
+
+// ReviewsOverLimit reports whether the review limit is too low to keep to
+// the plan.
+func (p Plan) ReviewsOverLimit() bool {
+	return p.ReviewsLimit > 0 && p.ReviewsPerDay > p.ReviewsLimit
+}
+
+// plan returns nil when the group has no target date or it has passed.
+func plan(c configuring.Config, st States, now time.Time) *Plan {
+	days := reviewing.DaysUntil(now, c.Target)
+	if c.Target.IsZero() || days <= 0 {
+		return nil
+	}
+
+	reviews := c.TargetReviews
+	if reviews <= 0 {
+		reviews = reviewing.DefaultTargetReviews
+	}
+	faces := st.New + st.Learning + st.Mature
+	return &Plan{
+		Target:        c.Target,
+		DaysLeft:      days,
+		NewPerDay:     ceilDiv(st.New, days),
+		ReviewsPerDay: ceilDiv(faces*reviews, days),
+		NewLimit:      c.NewPerDay,
+		ReviewsLimit:  c.ReviewsPerDay,
+	}
+}
+
+func ceilDiv(n, d int) int {
+	return (n + d - 1) / d
+}
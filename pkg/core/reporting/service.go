@@ -0,0 +1,167 @@
+package reporting
+
+import (
+	"math"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/logging"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/storage"
+)
+
+type Service interface {
+	GetStats(string) (Stats, error)
+}
+
+type service struct {
+	g     getting.Service
+	l     logging.Service
+	c     configuring.Service
+	clock storage.Clock
+}
+
+func New(g getting.Service, l logging.Service, c configuring.Service, clock storage.Clock) *service {
+	return &service{g, l, c, clock}
+}
+
+// GetStats reports on the unsuspended cards under the group and their
+// reviews, and on the work left before the group's target date.
+func (s *service) GetStats(g string) (Stats, error) {
+	cards, err := s.g.GetAllCards(g, getting.Unsuspended)
+	if err != nil {
+		return Stats{}, err
+	}
+	reviews, err := s.l.GetReviews(logging.Query{Group: g})
+	if err != nil {
+		return Stats{}, err
+	}
+	config, err := configuring.Resolve(s.c.GetConfig, g)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	now := s.clock.Now()
+	st := states(cards)
+	return Stats{
+		States:     st,
+		Retention:  retention(reviews, now),
+		Streak:     streak(reviews, now),
+		AnswerTime: answerTime(reviews),
+		Forecast:   forecast(cards, now),
+		Plan:       plan(config, st, now),
+	}, nil
+}
+
+func states(cards []getting.Card) States {
+	var st States
+	for _, c := range cards {
+		for _, f := range c.Faces() {
+			sched, ok := c.Schedules[f]
+			switch {
+			case !ok:
+				st.New++
+			case sched.Interval >= matureInterval:
+				st.Mature++
+			default:
+				st.Learning++
+			}
+		}
+	}
+	return st
+}
+
+// retention only counts reviews of faces that had been seen before, since
+// a first review says nothing about what was remembered.
+func retention(reviews []logging.Review, now time.Time) []Retention {
+	today := startOfDay(now)
+	rs := make([]Retention, len(retentionDays))
+	for i, days := range retentionDays {
+		rs[i].Days = days
+		from := today.AddDate(0, 0, 1-days)
+		for _, r := range reviews {
+			if r.LastInterval == 0 || r.Time.Before(from) || r.Time.After(now) {
+				continue
+			}
+			rs[i].Reviews++
+			if r.Grade != reviewing.Again {
+				rs[i].Recalled++
+			}
+		}
+	}
+	return rs
+}
+
+func streak(reviews []logging.Review, now time.Time) Streak {
+	reviewed := map[time.Time]bool{}
+	for _, r := range reviews {
+		reviewed[startOfDay(r.Time.In(now.Location()))] = true
+	}
+
+	var st Streak
+	for day := range reviewed {
+		if reviewed[day.AddDate(0, 0, -1)] {
+			continue // not the first day of a streak
+		}
+		n := 0
+		for reviewed[day.AddDate(0, 0, n)] {
+			n++
+		}
+		if n > st.Longest {
+			st.Longest = n
+		}
+	}
+
+	day := startOfDay(now)
+	if !reviewed[day] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for reviewed[day] {
+		st.Current++
+		day = day.AddDate(0, 0, -1)
+	}
+	return st
+}
+
+// answerTime averages the reviews that were timed.
+func answerTime(reviews []logging.Review) time.Duration {
+	var total time.Duration
+	n := 0
+	for _, r := range reviews {
+		if r.Duration > 0 {
+			total += r.Duration
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return total / time.Duration(n)
+}
+
+func forecast(cards []getting.Card, now time.Time) []Due {
+	today := startOfDay(now)
+	days := make([]Due, ForecastDays)
+	for d := range days {
+		days[d].Day = today.AddDate(0, 0, d)
+	}
+	for _, c := range cards {
+		for _, f := range c.Faces() {
+			due := startOfDay(c.Schedule(f).Due.In(now.Location()))
+			d := int(math.Round(due.Sub(today).Hours() / 24))
+			if d < 0 {
+				d = 0
+			}
+			if d < ForecastDays {
+				days[d].Faces++
+			}
+		}
+	}
+	return days
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,291 @@
+package reporting
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/logging"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+)
+
+var errGroupNotFound error = errors.New("group not found")
+
+var now = time.Date(2022, time.November, 30, 12, 0, 0, 0, time.UTC)
+
+type clockStub struct{}
+
+func (c *clockStub) Now() time.Time {
+	return now
+}
+
+type gettingStub struct {
+	cards []getting.Card
+}
+
+func newGettingStubWithCards() *gettingStub {
+	return &gettingStub{
+		cards: []getting.Card{
+			{Title: "Group.New", Created: now.AddDate(0, 0, -1)},
+			{
+				Title: "Group.Learning", Reversible: true, Created: now.AddDate(0, 0, -10),
+				Schedules: map[string]getting.Schedule{
+					getting.Forward: {Due: now.AddDate(0, 0, 1), Interval: 6},
+				},
+			},
+			{
+				Title: "Group.Mature",
+				Schedules: map[string]getting.Schedule{
+					getting.Forward: {Due: now.AddDate(0, 0, 25), Interval: 30},
+				},
+			},
+			{
+				Title: "Group.Later",
+				Schedules: map[string]getting.Schedule{
+					getting.Forward: {Due: now.AddDate(0, 0, 60), Interval: 90},
+				},
+			},
+		},
+	}
+}
+
+func (g *gettingStub) GetCards(string, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (g *gettingStub) GetAllCards(group string, _ getting.Filter) ([]getting.Card, error) {
+	if group != "Group" {
+		return []getting.Card{}, errGroupNotFound
+	}
+	return g.cards, nil
+}
+
+func (g *gettingStub) GetCardsInBox(string, int, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (g *gettingStub) GetLeeches(string) ([]getting.Card, error) {
+	return nil, nil
+}
+
+type loggingStub struct {
+	reviews []logging.Review
+}
+
+func (l *loggingStub) GetReviews(q logging.Query) ([]logging.Review, error) {
+	return l.reviews, nil
+}
+
+type configuringStub struct {
+	configs map[string]configuring.Config
+}
+
+func (c *configuringStub) GetConfig(g string) (configuring.Config, error) {
+	return c.configs[g], nil
+}
+
+func (c *configuringStub) SetConfig(string, configuring.Config) error {
+	return nil
+}
+
+// day returns a review of a previously seen card the number of days ago.
+func day(ago int, g reviewing.Grade, d time.Duration) logging.Review {
+	return logging.Review{
+		Card: "Group.Learning", Time: now.AddDate(0, 0, -ago), Grade: g,
+		Duration: d, LastInterval: 1,
+	}
+}
+
+func TestGetStats(t *testing.T) {
+	reviews := []logging.Review{
+		{Card: "Group.Learning", Time: now.AddDate(0, 0, -100), Grade: reviewing.Again},
+		day(95, reviewing.Again, 0),
+		day(60, reviewing.Good, 0),
+		day(59, reviewing.Good, 0),
+		day(58, reviewing.Good, 0),
+		day(20, reviewing.Again, 0),
+		day(5, reviewing.Good, 4*time.Second),
+		day(2, reviewing.Again, 2*time.Second),
+		day(1, reviewing.Good, 6*time.Second),
+		day(1, reviewing.Hard, 0),
+	}
+
+	tests := []struct {
+		name    string
+		group   string
+		want    Stats
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			want: Stats{
+				States: States{New: 2, Learning: 1, Mature: 2},
+				Retention: []Retention{
+					{Days: 7, Reviews: 4, Recalled: 3},
+					{Days: 30, Reviews: 5, Recalled: 3},
+					{Days: 90, Reviews: 8, Recalled: 6},
+				},
+				Streak:     Streak{Current: 2, Longest: 3},
+				AnswerTime: 4 * time.Second,
+				Forecast:   forecastOf(map[int]int{0: 2, 1: 1, 25: 1}),
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Group Not Found",
+			group:   "NotFound",
+			want:    Stats{},
+			wantErr: errGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := New(newGettingStubWithCards(), &loggingStub{reviews}, &configuringStub{}, &clockStub{})
+			got, err := rs.GetStats(tt.group)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect stats. Want %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetStatsPlan(t *testing.T) {
+	target := time.Date(2022, time.December, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		configs map[string]configuring.Config
+		want    *Plan
+	}{
+		{
+			name: "Normal",
+			configs: map[string]configuring.Config{
+				"Group": {Target: target, TargetReviews: 2, NewPerDay: 5},
+			},
+			want: &Plan{Target: target, DaysLeft: 10, NewPerDay: 1, ReviewsPerDay: 1, NewLimit: 5},
+		},
+		{
+			name: "Inherited",
+			configs: map[string]configuring.Config{
+				"":      {Target: target, ReviewsPerDay: 20},
+				"Group": {NewPerDay: -1},
+			},
+			want: &Plan{Target: target, DaysLeft: 10, NewPerDay: 1, ReviewsPerDay: 1, NewLimit: -1, ReviewsLimit: 20},
+		},
+		{
+			name: "Target Passed",
+			configs: map[string]configuring.Config{
+				"Group": {Target: now.AddDate(0, 0, -1)},
+			},
+			want: nil,
+		},
+		{
+			name:    "No Target",
+			configs: map[string]configuring.Config{},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := New(newGettingStubWithCards(), &loggingStub{}, &configuringStub{tt.configs}, &clockStub{})
+			got, err := rs.GetStats("Group")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got.Plan) {
+				t.Errorf("Incorrect plan. Want %+v, got %+v", tt.want, got.Plan)
+			}
+		})
+	}
+}
+
+func TestPlanOverLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		plan        Plan
+		wantNew     bool
+		wantReviews bool
+	}{
+		{"Within Limits", Plan{NewPerDay: 5, ReviewsPerDay: 20, NewLimit: 5, ReviewsLimit: 50}, false, false},
+		{"Over New Limit", Plan{NewPerDay: 6, NewLimit: 5}, true, false},
+		{"Over Review Limit", Plan{ReviewsPerDay: 60, ReviewsLimit: 50}, false, true},
+		{"Unlimited", Plan{NewPerDay: 100, ReviewsPerDay: 100, NewLimit: -1}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.plan.NewOverLimit(); got != tt.wantNew {
+				t.Errorf("Incorrect new over limit. Want %v, got %v", tt.wantNew, got)
+			}
+			if got := tt.plan.ReviewsOverLimit(); got != tt.wantReviews {
+				t.Errorf("Incorrect reviews over limit. Want %v, got %v", tt.wantReviews, got)
+			}
+		})
+	}
+}
+
+func forecastOf(due map[int]int) []Due {
+	today := time.Date(2022, time.November, 30, 0, 0, 0, 0, time.UTC)
+	days := make([]Due, ForecastDays)
+	for d := range days {
+		days[d] = Due{Day: today.AddDate(0, 0, d), Faces: due[d]}
+	}
+	return days
+}
+
+func TestStreak(t *testing.T) {
+	tests := []struct {
+		name    string
+		reviews []logging.Review
+		want    Streak
+	}{
+		{
+			name:    "No Reviews",
+			reviews: nil,
+			want:    Streak{},
+		},
+		{
+			name: "Reviewed Today",
+			reviews: []logging.Review{
+				day(0, reviewing.Good, 0), day(1, reviewing.Good, 0), day(3, reviewing.Good, 0),
+			},
+			want: Streak{Current: 2, Longest: 2},
+		},
+		{
+			name: "Not Yet Reviewed Today",
+			reviews: []logging.Review{
+				day(1, reviewing.Good, 0), day(2, reviewing.Good, 0), day(2, reviewing.Good, 0),
+			},
+			want: Streak{Current: 2, Longest: 2},
+		},
+		{
+			name: "Broken",
+			reviews: []logging.Review{
+				day(2, reviewing.Good, 0), day(10, reviewing.Good, 0), day(11, reviewing.Good, 0),
+				day(12, reviewing.Good, 0),
+			},
+			want: Streak{Current: 0, Longest: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := streak(tt.reviews, now)
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect streak. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
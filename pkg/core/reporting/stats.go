@@ -0,0 +1,62 @@
+package reporting
+
+import "time"
+
+// Faces are mature once their interval reaches this many days.
+const matureInterval = 21
+
+// ForecastDays is the number of days that due faces are forecast for.
+const ForecastDays = 30
+
+// retentionDays are the days that retention is measured over.
+var retentionDays = []int{7, 30, 90}
+
+type Stats struct {
+	States    States
+	Retention []Retention
+	Streak    Streak
+	// AnswerTime is the average time taken to answer a card.
+	AnswerTime time.Duration
+	// Forecast counts the faces due each day from today, with overdue
+	// faces counted today.
+	Forecast []Due
+	// Plan is the work left before the group's target date, if it has one.
+	Plan *Plan
+}
+
+// Due is the number of faces due on a day.
+type Due struct {
+	Day   time.Time
+	Faces int
+}
+
+// States counts card faces by how well they are known.
+type States struct {
+	New      int
+	Learning int
+	Mature   int
+}
+
+// Retention is the share of reviews of previously seen faces that were
+// recalled over a number of days.
+type Retention struct {
+	Days     int
+	Reviews  int
+	Recalled int
+}
+
+// Rate returns the share of reviews that were recalled, or 0 without any
+// reviews.
+func (r Retention) Rate() float64 {
+	if r.Reviews == 0 {
+		return 0
+	}
+	return float64(r.Recalled) / float64(r.Reviews)
+}
+
+// Streak counts days in a row with at least one review. The current streak
+// ends today, or yesterday if nothing has been reviewed yet today.
+type Streak struct {
+	Current int
+	Longest int
+}
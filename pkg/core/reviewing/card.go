@@ -0,0 +1,34 @@
+package reviewing
+
+import "time"
+
+// Forward is the face of a card reviewed when no face is given.
+const Forward = "forward"
+
+type Card struct {
+	Title string
+	Face  string
+	Due   time.Time
+	State State
+	// Duration is how long the card took to answer, kept in the review log.
+	Duration time.Duration
+	// Leech is set when the review made the card a leech.
+	Leech bool
+	// Cram is set for reviews in a cram session, which never count as lapses.
+	Cram bool
+}
+
+// State is the memory state a Scheduler keeps for a card between reviews.
+type State struct {
+	Interval    int
+	EaseFactor  float64
+	Repetitions int
+	Stability   float64
+	Difficulty  float64
+	Box         int
+	Reviewed    time.Time
+	// Lapses counts how many times the card was forgotten after being learned.
+	Lapses int
+	// Relearning is set once the card lapses, until it is next recalled.
+	Relearning bool
+}
@@ -0,0 +1,129 @@
+package reviewing
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+const (
+	fsrsDecay  = -0.5
+	fsrsFactor = 19.0 / 81.0
+
+	defaultRetention = 0.9
+	maxInterval      = 36500
+)
+
+var (
+	ErrInvalidWeights   error = errors.New("fsrs requires 17 weights")
+	ErrInvalidRetention error = errors.New("desired retention must be between 0 and 1")
+)
+
+var defaultWeights = []float64{
+	0.4872, 1.4003, 3.7145, 13.8206, 5.1618, 1.2298, 0.8975, 0.031, 1.6474,
+	0.1367, 1.0461, 2.1072, 0.0793, 0.3246, 1.587, 0.2272, 2.8755,
+}
+
+// DefaultWeights returns a copy of the default FSRS-4.5 weights.
+func DefaultWeights() []float64 {
+	w := make([]float64, len(defaultWeights))
+	copy(w, defaultWeights)
+	return w
+}
+
+type fsrs struct {
+	w         []float64
+	retention float64
+}
+
+// NewFSRS returns an FSRS-4.5 scheduler. Nil weights and a zero retention
+// select the defaults.
+func NewFSRS(w []float64, retention float64) (*fsrs, error) {
+	if w == nil {
+		w = defaultWeights
+	}
+	if len(w) != len(defaultWeights) {
+		return nil, ErrInvalidWeights
+	}
+	if retention == 0 {
+		retention = defaultRetention
+	}
+	if retention <= 0 || retention >= 1 {
+		return nil, ErrInvalidRetention
+	}
+	return &fsrs{w, retention}, nil
+}
+
+// Retrievability returns the probability of recalling a card with the
+// given stability after the elapsed number of days.
+func Retrievability(elapsed, stability float64) float64 {
+	return math.Pow(1+fsrsFactor*elapsed/stability, fsrsDecay)
+}
+
+// Schedule computes the card's next state using the FSRS-4.5 algorithm.
+func (f *fsrs) Schedule(st State, g Grade, now time.Time) (State, time.Time) {
+	rating := float64(g) + 1
+
+	if st.Stability == 0 {
+		st.Stability = f.initStability(rating)
+		st.Difficulty = f.initDifficulty(rating)
+	} else {
+		elapsed := math.Max(0, math.Floor(now.Sub(st.Reviewed).Hours()/24))
+		r := Retrievability(elapsed, st.Stability)
+		if g == Again {
+			st.Stability = f.forgetStability(st.Difficulty, st.Stability, r)
+		} else {
+			st.Stability = f.recallStability(st.Difficulty, st.Stability, r, g)
+		}
+		st.Difficulty = f.nextDifficulty(st.Difficulty, rating)
+	}
+
+	if g == Again {
+		st.Repetitions = 0
+	} else {
+		st.Repetitions++
+	}
+	st.Interval = f.interval(st.Stability)
+	st.Reviewed = now
+	return st, now.AddDate(0, 0, st.Interval)
+}
+
+func (f *fsrs) initStability(rating float64) float64 {
+	return math.Max(f.w[int(rating)-1], 0.1)
+}
+
+func (f *fsrs) initDifficulty(rating float64) float64 {
+	return clamp(f.w[4]-(rating-3)*f.w[5], 1, 10)
+}
+
+func (f *fsrs) nextDifficulty(d, rating float64) float64 {
+	next := d - f.w[6]*(rating-3)
+	// Mean reversion towards the initial difficulty of a "good" answer.
+	return clamp(f.w[7]*f.initDifficulty(3)+(1-f.w[7])*next, 1, 10)
+}
+
+func (f *fsrs) recallStability(d, s, r float64, g Grade) float64 {
+	hardPenalty, easyBonus := 1.0, 1.0
+	if g == Hard {
+		hardPenalty = f.w[15]
+	}
+	if g == Easy {
+		easyBonus = f.w[16]
+	}
+	return s * (1 + math.Exp(f.w[8])*(11-d)*math.Pow(s, -f.w[9])*
+		(math.Exp((1-r)*f.w[10])-1)*hardPenalty*easyBonus)
+}
+
+func (f *fsrs) forgetStability(d, s, r float64) float64 {
+	return f.w[11] * math.Pow(d, -f.w[12]) * (math.Pow(s+1, f.w[13]) - 1) *
+		math.Exp((1-r)*f.w[14])
+}
+
+func (f *fsrs) interval(s float64) int {
+	i := s / fsrsFactor * (math.Pow(f.retention, 1/fsrsDecay) - 1)
+	return int(clamp(math.Round(i), 1, maxInterval))
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Min(math.Max(v, lo), hi)
+}
@@ -0,0 +1,39 @@
+package reviewing
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidGrade error = errors.New("invalid grade")
+
+type Grade int
+
+const (
+	Again Grade = iota
+	Hard
+	Good
+	Easy
+)
+
+var gradeNames = []string{"again", "hard", "good", "easy"}
+
+func ParseGrade(s string) (Grade, error) {
+	for i, n := range gradeNames {
+		if strings.EqualFold(s, n) {
+			return Grade(i), nil
+		}
+	}
+	return 0, ErrInvalidGrade
+}
+
+func (g Grade) Valid() bool {
+	return g >= Again && g <= Easy
+}
+
+func (g Grade) String() string {
+	if !g.Valid() {
+		return "invalid"
+	}
+	return gradeNames[g]
+}
@@ -0,0 +1,31 @@
+package reviewing
+
+import "errors"
+
+var ErrUnknownLeechAction error = errors.New("unknown leech action")
+
+// Actions taken on a card once it becomes a leech.
+const (
+	LeechTag     = "tag"     // Mark the card as a leech
+	LeechSuspend = "suspend" // Mark the card as a leech and suspend it
+)
+
+// DefaultLeechThreshold is the number of lapses that make a card a leech
+// when none of its groups set a threshold.
+const DefaultLeechThreshold = 8
+
+// lapsed reports whether the grade forgets a face that had been learned,
+// i.e. one that was recalled since it was new and is not still being
+// relearned after an earlier lapse.
+func lapsed(s State, g Grade) bool {
+	return g == Again && s.Repetitions >= 1 && !s.Relearning
+}
+
+// leech reports whether a face with the number of lapses is a leech.
+func (s Settings) leech(lapses int) bool {
+	threshold := s.LeechThreshold
+	if threshold == 0 {
+		threshold = DefaultLeechThreshold
+	}
+	return lapses >= threshold
+}
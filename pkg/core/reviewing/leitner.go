@@ -0,0 +1,50 @@
+package reviewing
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidBoxes error = errors.New("leitner boxes must have positive intervals")
+
+var defaultBoxes = []int{1, 2, 4, 8, 16}
+
+type leitner struct {
+	boxes []int
+}
+
+// NewLeitner returns a Leitner box scheduler where boxes holds the review
+// interval, in days, of each box. Nil boxes select the defaults.
+func NewLeitner(boxes []int) (*leitner, error) {
+	if boxes == nil {
+		boxes = defaultBoxes
+	}
+	if len(boxes) == 0 {
+		return nil, ErrInvalidBoxes
+	}
+	for _, b := range boxes {
+		if b <= 0 {
+			return nil, ErrInvalidBoxes
+		}
+	}
+	return &leitner{boxes}, nil
+}
+
+// Schedule moves the card up a box when it was recalled and back to the
+// first box when it was missed.
+func (l *leitner) Schedule(st State, g Grade, now time.Time) (State, time.Time) {
+	if g == Again {
+		st.Box = 1
+		st.Repetitions = 0
+	} else {
+		st.Box++
+		if st.Box > len(l.boxes) {
+			st.Box = len(l.boxes)
+		}
+		st.Repetitions++
+	}
+
+	st.Interval = l.boxes[st.Box-1]
+	st.Reviewed = now
+	return st, now.AddDate(0, 0, st.Interval)
+}
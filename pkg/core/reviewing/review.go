@@ -0,0 +1,17 @@
+package reviewing
+
+import "time"
+
+// Review is an entry in the review log, recorded each time a card's face is
+// graded.
+type Review struct {
+	// Card is the card's full path.
+	Card         string
+	Face         string
+	Time         time.Time
+	Grade        Grade
+	Duration     time.Duration
+	LastInterval int
+	Interval     int
+	Scheduler    string
+}
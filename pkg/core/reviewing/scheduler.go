@@ -0,0 +1,35 @@
+package reviewing
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	SM2     = "sm2"
+	FSRS    = "fsrs"
+	Leitner = "leitner"
+)
+
+var ErrUnknownScheduler error = errors.New("unknown scheduler")
+
+// Scheduler computes a card's next memory state and due time from its
+// current state and the grade it was given at the time of review.
+type Scheduler interface {
+	Schedule(State, Grade, time.Time) (State, time.Time)
+}
+
+// NewScheduler returns the Scheduler selected by the settings, defaulting
+// to SM-2 when none is set.
+func NewScheduler(s Settings) (Scheduler, error) {
+	switch s.Scheduler {
+	case "", SM2:
+		return NewSM2(), nil
+	case FSRS:
+		return NewFSRS(s.Weights, s.Retention)
+	case Leitner:
+		return NewLeitner(s.Boxes)
+	default:
+		return nil, ErrUnknownScheduler
+	}
+}
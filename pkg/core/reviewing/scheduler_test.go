@@ -0,0 +1,221 @@
+package reviewing
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewScheduler(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings Settings
+		wantErr  error
+	}{
+		{name: "Default", settings: Settings{}, wantErr: nil},
+		{name: "SM2", settings: Settings{Scheduler: SM2}, wantErr: nil},
+		{name: "FSRS", settings: Settings{Scheduler: FSRS}, wantErr: nil},
+		{
+			name:     "FSRS Custom",
+			settings: Settings{Scheduler: FSRS, Weights: DefaultWeights(), Retention: 0.85},
+			wantErr:  nil,
+		},
+		{
+			name:     "FSRS Invalid Weights",
+			settings: Settings{Scheduler: FSRS, Weights: []float64{1, 2, 3}},
+			wantErr:  ErrInvalidWeights,
+		},
+		{
+			name:     "FSRS Invalid Retention",
+			settings: Settings{Scheduler: FSRS, Retention: 1.5},
+			wantErr:  ErrInvalidRetention,
+		},
+		{name: "Leitner", settings: Settings{Scheduler: Leitner}, wantErr: nil},
+		{
+			name:     "Leitner Invalid Boxes",
+			settings: Settings{Scheduler: Leitner, Boxes: []int{1, 0}},
+			wantErr:  ErrInvalidBoxes,
+		},
+		{name: "Unknown", settings: Settings{Scheduler: "unknown"}, wantErr: ErrUnknownScheduler},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewScheduler(tt.settings)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings Settings
+		wantErr  error
+	}{
+		{name: "Default", settings: Settings{}, wantErr: nil},
+		{name: "SM2", settings: Settings{Scheduler: SM2}, wantErr: nil},
+		{name: "FSRS", settings: Settings{Scheduler: FSRS, Retention: 0.85}, wantErr: nil},
+		{name: "Leitner", settings: Settings{Scheduler: Leitner, Boxes: []int{1, 3, 7}}, wantErr: nil},
+		{name: "Unknown Scheduler", settings: Settings{Scheduler: "unknown"}, wantErr: ErrUnknownScheduler},
+		{
+			name:     "Invalid Weights",
+			settings: Settings{Scheduler: FSRS, Weights: []float64{0.4}},
+			wantErr:  ErrInvalidWeights,
+		},
+		{
+			name:     "Invalid Inherited Scheduler Retention",
+			settings: Settings{Retention: 1},
+			wantErr:  ErrInvalidRetention,
+		},
+		{
+			name:     "Invalid Boxes",
+			settings: Settings{Scheduler: Leitner, Boxes: []int{1, -3}},
+			wantErr:  ErrInvalidBoxes,
+		},
+		{name: "Leech Action", settings: Settings{LeechAction: LeechSuspend}, wantErr: nil},
+		{name: "Unknown Leech Action", settings: Settings{LeechAction: "delete"}, wantErr: ErrUnknownLeechAction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.settings)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFSRSSchedule(t *testing.T) {
+	reviewed := now.AddDate(0, 0, -4)
+	tests := []struct {
+		name      string
+		retention float64
+		state     State
+		grade     Grade
+		want      State
+	}{
+		{
+			name:  "New Again",
+			state: State{},
+			grade: Again,
+			want:  State{Interval: 1, Stability: 0.49, Difficulty: 7.62, Reviewed: now},
+		},
+		{
+			name:  "New Hard",
+			state: State{},
+			grade: Hard,
+			want:  State{Interval: 1, Repetitions: 1, Stability: 1.4, Difficulty: 6.39, Reviewed: now},
+		},
+		{
+			name:      "New Good Lower Retention",
+			retention: 0.8,
+			state:     State{},
+			grade:     Good,
+			want:      State{Interval: 9, Repetitions: 1, Stability: 3.71, Difficulty: 5.16, Reviewed: now},
+		},
+		{
+			name:  "Review Good",
+			state: State{Interval: 4, Repetitions: 1, Stability: 3.71, Difficulty: 5.16, Reviewed: reviewed},
+			grade: Good,
+			want:  State{Interval: 15, Repetitions: 2, Stability: 14.81, Difficulty: 5.16, Reviewed: now},
+		},
+		{
+			name:  "Review Again",
+			state: State{Interval: 4, Repetitions: 1, Stability: 3.71, Difficulty: 5.16, Reviewed: reviewed},
+			grade: Again,
+			want:  State{Interval: 1, Repetitions: 0, Stability: 1.43, Difficulty: 6.9, Reviewed: now},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFSRS(nil, tt.retention)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got, due := f.Schedule(tt.state, tt.grade, now)
+
+			got = round(got)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect state. Want %v, got %v", tt.want, got)
+			}
+
+			if want := now.AddDate(0, 0, tt.want.Interval); !due.Equal(want) {
+				t.Errorf("Incorrect due. Want %v, got %v", want, due)
+			}
+		})
+	}
+}
+
+func TestRetrievability(t *testing.T) {
+	// By construction, recall probability drops to 90% after one stability.
+	if r := Retrievability(10, 10); r < 0.8999 || r > 0.9001 {
+		t.Errorf("Incorrect retrievability. Want 0.9, got %v", r)
+	}
+	if r := Retrievability(0, 10); r != 1 {
+		t.Errorf("Incorrect retrievability. Want 1, got %v", r)
+	}
+}
+
+func TestLeitnerSchedule(t *testing.T) {
+	tests := []struct {
+		name  string
+		state State
+		grade Grade
+		want  State
+	}{
+		{
+			name:  "New Good",
+			state: State{},
+			grade: Good,
+			want:  State{Interval: 1, Repetitions: 1, Box: 1, Reviewed: now},
+		},
+		{
+			name:  "New Again",
+			state: State{},
+			grade: Again,
+			want:  State{Interval: 1, Box: 1, Reviewed: now},
+		},
+		{
+			name:  "Move Up",
+			state: State{Interval: 1, Repetitions: 1, Box: 1},
+			grade: Hard,
+			want:  State{Interval: 3, Repetitions: 2, Box: 2, Reviewed: now},
+		},
+		{
+			name:  "Last Box",
+			state: State{Interval: 7, Repetitions: 5, Box: 3},
+			grade: Easy,
+			want:  State{Interval: 7, Repetitions: 6, Box: 3, Reviewed: now},
+		},
+		{
+			name:  "Missed",
+			state: State{Interval: 7, Repetitions: 5, Box: 3},
+			grade: Again,
+			want:  State{Interval: 1, Box: 1, Reviewed: now},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := NewLeitner([]int{1, 3, 7})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got, due := l.Schedule(tt.state, tt.grade, now)
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect state. Want %v, got %v", tt.want, got)
+			}
+
+			if want := now.AddDate(0, 0, tt.want.Interval); !due.Equal(want) {
+				t.Errorf("Incorrect due. Want %v, got %v", want, due)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package reviewing
+
+import (
+	"errors"
+
+	"github.com/jmcveigh55/flash/pkg/storage"
+)
+
+var ErrCardEmptyTitle error = errors.New("card has an empty title")
+
+type Service interface {
+	ReviewCard(string, Card, Grade) (Card, error)
+}
+
+type Repository interface {
+	GetSchedule(string, Card) (Card, error)
+	UpdateSchedule(string, Card) error
+	// GetReviewSettings returns the group's settings, with any it leaves
+	// unset inherited from its parent groups.
+	GetReviewSettings(string) (Settings, error)
+	AddReview(Review) error
+	MarkLeech(string, Card, bool) error
+}
+
+type service struct {
+	r     Repository
+	clock storage.Clock
+}
+
+func New(r Repository, c storage.Clock) *service {
+	return &service{r, c}
+}
+
+func (s *service) ReviewCard(g string, c Card, gr Grade) (Card, error) {
+	if c.Title == "" {
+		return Card{}, ErrCardEmptyTitle
+	}
+	if !gr.Valid() {
+		return Card{}, ErrInvalidGrade
+	}
+	if c.Face == "" {
+		c.Face = Forward
+	}
+
+	settings, err := s.r.GetReviewSettings(g)
+	if err != nil {
+		return Card{}, err
+	}
+	sched, err := NewScheduler(settings)
+	if err != nil {
+		return Card{}, err
+	}
+
+	card, err := s.r.GetSchedule(g, c)
+	if err != nil {
+		return Card{}, err
+	}
+
+	now := s.clock.Now()
+	last := card.State.Interval
+	lapses := card.State.Lapses
+	relearning := card.State.Relearning && gr == Again
+	lapse := !c.Cram && lapsed(card.State, gr)
+	card.State, card.Due = sched.Schedule(card.State, gr, now)
+	card.State, card.Due = settings.capInterval(card.State, card.Due, now)
+	card.State.Lapses = lapses
+	card.State.Relearning = relearning
+	if lapse {
+		card.State.Lapses++
+		card.State.Relearning = true
+		card.Leech = settings.leech(card.State.Lapses)
+	}
+	if err := s.r.UpdateSchedule(g, card); err != nil {
+		return Card{}, err
+	}
+	if card.Leech {
+		if err := s.r.MarkLeech(g, card, settings.LeechAction == LeechSuspend); err != nil {
+			return Card{}, err
+		}
+	}
+
+	scheduler := settings.Scheduler
+	if scheduler == "" {
+		scheduler = SM2
+	}
+	review := Review{
+		Card:         storage.JoinPath(g, c.Title),
+		Face:         c.Face,
+		Time:         now,
+		Grade:        gr,
+		Duration:     c.Duration,
+		LastInterval: last,
+		Interval:     card.State.Interval,
+		Scheduler:    scheduler,
+	}
+	return card, s.r.AddReview(review)
+}
@@ -0,0 +1,536 @@
+package reviewing
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var errCardNotFound error = errors.New("card not found")
+
+var now = time.Date(2022, time.November, 1, 12, 0, 0, 0, time.UTC)
+
+type clockStub struct{}
+
+func (c *clockStub) Now() time.Time {
+	return now
+}
+
+type repositoryStub struct {
+	cards    []Card
+	settings map[string]Settings
+	reviews  []Review
+	leeches  map[string]bool
+}
+
+func newRepositoryStubWithCards() *repositoryStub {
+	return &repositoryStub{
+		cards: []Card{
+			{Title: "Group.New", Face: Forward},
+			{Title: "Group.Learning", Face: Forward, State: State{Interval: 1, EaseFactor: 2.5, Repetitions: 1}},
+			{Title: "Group.Mature", Face: Forward, State: State{Interval: 6, EaseFactor: 2.5, Repetitions: 2}},
+			{Title: "Group.Hard", Face: Forward, State: State{Interval: 10, EaseFactor: 1.3, Repetitions: 3}},
+			{Title: "Fsrs.New", Face: Forward},
+			{Title: "Fsrs.SubGroup.New", Face: Forward},
+			{Title: "Unknown.New", Face: Forward},
+			{Title: "Leitner.Boxed", Face: Forward, State: State{Interval: 2, Repetitions: 2, Box: 2}},
+			{Title: "Group.Reversible", Face: Forward, State: State{Interval: 6, EaseFactor: 2.5, Repetitions: 2}},
+			{Title: "Group.Reversible", Face: "reverse"},
+			{Title: "Exam.Mature", Face: Forward, State: State{Interval: 6, EaseFactor: 2.5, Repetitions: 2}},
+			{Title: "Exam.SubGroup.Mature", Face: Forward, State: State{Interval: 6, EaseFactor: 2.5, Repetitions: 2}},
+			{Title: "Passed.Mature", Face: Forward, State: State{Interval: 6, EaseFactor: 2.5, Repetitions: 2}},
+			{Title: "Group.Lapsed", Face: Forward, State: State{Interval: 10, EaseFactor: 2.5, Repetitions: 3, Lapses: 7}},
+			{Title: "Leech.SubGroup.Lapsed", Face: Forward, State: State{Interval: 10, EaseFactor: 2.5, Repetitions: 3, Lapses: 2}},
+			{Title: "Group.Relearning", Face: Forward, State: State{Interval: 1, EaseFactor: 2.5, Lapses: 7, Relearning: true}},
+		},
+		// Settings are given as the repository resolves them, subgroups
+		// included.
+		settings: map[string]Settings{
+			"Fsrs":           {Scheduler: FSRS},
+			"Fsrs.SubGroup":  {Scheduler: FSRS},
+			"Unknown":        {Scheduler: "unknown"},
+			"Leitner":        {Scheduler: Leitner, Boxes: []int{1, 2, 5}},
+			"Leech":          {LeechThreshold: 3, LeechAction: LeechSuspend},
+			"Leech.SubGroup": {LeechThreshold: 3, LeechAction: LeechSuspend},
+			"Exam":           {Target: now.AddDate(0, 0, 10), TargetReviews: 2},
+			"Exam.SubGroup":  {Target: now.AddDate(0, 0, 10), TargetReviews: 2},
+			"Passed":         {Target: now.AddDate(0, 0, -1)},
+		},
+	}
+}
+
+func (r *repositoryStub) GetSchedule(g string, c Card) (Card, error) {
+	title := g + "." + c.Title
+	for _, card := range r.cards {
+		if card.Title == title && card.Face == c.Face {
+			card.Title = c.Title
+			return card, nil
+		}
+	}
+	return Card{}, errCardNotFound
+}
+
+func (r *repositoryStub) UpdateSchedule(g string, c Card) error {
+	title := g + "." + c.Title
+	for i := range r.cards {
+		if r.cards[i].Title == title && r.cards[i].Face == c.Face {
+			c.Title = title
+			r.cards[i] = c
+			return nil
+		}
+	}
+	return errCardNotFound
+}
+
+func (r *repositoryStub) GetReviewSettings(g string) (Settings, error) {
+	return r.settings[g], nil
+}
+
+func (r *repositoryStub) MarkLeech(g string, c Card, suspend bool) error {
+	if r.leeches == nil {
+		r.leeches = map[string]bool{}
+	}
+	r.leeches[g+"."+c.Title] = suspend
+	return nil
+}
+
+func (r *repositoryStub) AddReview(rv Review) error {
+	r.reviews = append(r.reviews, rv)
+	return nil
+}
+
+// round trims float noise so states can be compared with reflect.DeepEqual.
+func round(st State) State {
+	st.EaseFactor = math.Round(st.EaseFactor*100) / 100
+	st.Stability = math.Round(st.Stability*100) / 100
+	st.Difficulty = math.Round(st.Difficulty*100) / 100
+	return st
+}
+
+func TestReviewCard(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		card    Card
+		grade   Grade
+		want    Card
+		wantErr error
+	}{
+		{
+			name:  "New Good",
+			group: "Group",
+			card:  Card{Title: "New"},
+			grade: Good,
+			want: Card{
+				Title: "New", Face: Forward, Due: now.AddDate(0, 0, 1),
+				State: State{Interval: 1, EaseFactor: 2.5, Repetitions: 1, Reviewed: now},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "New Again",
+			group: "Group",
+			card:  Card{Title: "New"},
+			grade: Again,
+			want: Card{
+				Title: "New", Face: Forward, Due: now.AddDate(0, 0, 1),
+				State: State{Interval: 1, EaseFactor: 2.5, Repetitions: 0, Reviewed: now},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Learning Easy",
+			group: "Group",
+			card:  Card{Title: "Learning"},
+			grade: Easy,
+			want: Card{
+				Title: "Learning", Face: Forward, Due: now.AddDate(0, 0, 6),
+				State: State{Interval: 6, EaseFactor: 2.6, Repetitions: 2, Reviewed: now},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Mature Good",
+			group: "Group",
+			card:  Card{Title: "Mature"},
+			grade: Good,
+			want: Card{
+				Title: "Mature", Face: Forward, Due: now.AddDate(0, 0, 15),
+				State: State{Interval: 15, EaseFactor: 2.5, Repetitions: 3, Reviewed: now},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Mature Again",
+			group: "Group",
+			card:  Card{Title: "Mature"},
+			grade: Again,
+			want: Card{
+				Title: "Mature", Face: Forward, Due: now.AddDate(0, 0, 1),
+				State: State{Interval: 1, EaseFactor: 2.5, Repetitions: 0, Reviewed: now, Lapses: 1, Relearning: true},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Ease Factor Floor",
+			group: "Group",
+			card:  Card{Title: "Hard"},
+			grade: Hard,
+			want: Card{
+				Title: "Hard", Face: Forward, Due: now.AddDate(0, 0, 13),
+				State: State{Interval: 13, EaseFactor: 1.3, Repetitions: 4, Reviewed: now},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Reverse Face",
+			group: "Group",
+			card:  Card{Title: "Reversible", Face: "reverse"},
+			grade: Good,
+			want: Card{
+				Title: "Reversible", Face: "reverse", Due: now.AddDate(0, 0, 1),
+				State: State{Interval: 1, EaseFactor: 2.5, Repetitions: 1, Reviewed: now},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Group Scheduler",
+			group: "Fsrs",
+			card:  Card{Title: "New"},
+			grade: Good,
+			want: Card{
+				Title: "New", Face: Forward, Due: now.AddDate(0, 0, 4),
+				State: State{Interval: 4, Repetitions: 1, Stability: 3.71, Difficulty: 5.16, Reviewed: now},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Subgroup Scheduler",
+			group: "Fsrs.SubGroup",
+			card:  Card{Title: "New"},
+			grade: Easy,
+			want: Card{
+				Title: "New", Face: Forward, Due: now.AddDate(0, 0, 14),
+				State: State{Interval: 14, Repetitions: 1, Stability: 13.82, Difficulty: 3.93, Reviewed: now},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Leitner Box",
+			group: "Leitner",
+			card:  Card{Title: "Boxed"},
+			grade: Good,
+			want: Card{
+				Title: "Boxed", Face: Forward, Due: now.AddDate(0, 0, 5),
+				State: State{Interval: 5, Repetitions: 3, Box: 3, Reviewed: now},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Target Date",
+			group: "Exam",
+			card:  Card{Title: "Mature"},
+			grade: Good,
+			want: Card{
+				Title: "Mature", Face: Forward, Due: now.AddDate(0, 0, 5),
+				State: State{Interval: 5, EaseFactor: 2.5, Repetitions: 3, Reviewed: now},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Subgroup Target Date",
+			group: "Exam.SubGroup",
+			card:  Card{Title: "Mature"},
+			grade: Easy,
+			want: Card{
+				Title: "Mature", Face: Forward, Due: now.AddDate(0, 0, 5),
+				State: State{Interval: 5, EaseFactor: 2.6, Repetitions: 3, Reviewed: now},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Target Date Passed",
+			group: "Passed",
+			card:  Card{Title: "Mature"},
+			grade: Good,
+			want: Card{
+				Title: "Mature", Face: Forward, Due: now.AddDate(0, 0, 15),
+				State: State{Interval: 15, EaseFactor: 2.5, Repetitions: 3, Reviewed: now},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Unknown Scheduler",
+			group:   "Unknown",
+			card:    Card{Title: "New"},
+			grade:   Good,
+			want:    Card{},
+			wantErr: ErrUnknownScheduler,
+		},
+		{
+			name:    "Card Not Found",
+			group:   "Group",
+			card:    Card{Title: "NotFound"},
+			grade:   Good,
+			want:    Card{},
+			wantErr: errCardNotFound,
+		},
+		{
+			name:    "Empty Title",
+			group:   "Group",
+			card:    Card{Title: ""},
+			grade:   Good,
+			want:    Card{},
+			wantErr: ErrCardEmptyTitle,
+		},
+		{
+			name:    "Invalid Grade",
+			group:   "Group",
+			card:    Card{Title: "New"},
+			grade:   Grade(7),
+			want:    Card{},
+			wantErr: ErrInvalidGrade,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepositoryStubWithCards()
+			rs := New(repo, &clockStub{})
+			got, err := rs.ReviewCard(tt.group, tt.card, tt.grade)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			got.State = round(got.State)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect card. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReviewCardLog(t *testing.T) {
+	tests := []struct {
+		name  string
+		group string
+		card  Card
+		grade Grade
+		want  []Review
+	}{
+		{
+			name:  "Default Scheduler",
+			group: "Group",
+			card:  Card{Title: "Mature", Duration: 5 * time.Second},
+			grade: Good,
+			want: []Review{{
+				Card: "Group.Mature", Face: Forward, Time: now, Grade: Good,
+				Duration: 5 * time.Second, LastInterval: 6, Interval: 15, Scheduler: SM2,
+			}},
+		},
+		{
+			name:  "Group Scheduler",
+			group: "Fsrs.SubGroup",
+			card:  Card{Title: "New", Duration: time.Second},
+			grade: Easy,
+			want: []Review{{
+				Card: "Fsrs.SubGroup.New", Face: Forward, Time: now, Grade: Easy,
+				Duration: time.Second, LastInterval: 0, Interval: 14, Scheduler: FSRS,
+			}},
+		},
+		{
+			name:  "Reverse Face",
+			group: "Group",
+			card:  Card{Title: "Reversible", Face: "reverse"},
+			grade: Again,
+			want: []Review{{
+				Card: "Group.Reversible", Face: "reverse", Time: now, Grade: Again,
+				LastInterval: 0, Interval: 1, Scheduler: SM2,
+			}},
+		},
+		{
+			name:  "Card Not Found",
+			group: "Group",
+			card:  Card{Title: "NotFound"},
+			grade: Good,
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepositoryStubWithCards()
+			rs := New(repo, &clockStub{})
+			rs.ReviewCard(tt.group, tt.card, tt.grade)
+
+			if !reflect.DeepEqual(tt.want, repo.reviews) {
+				t.Errorf("Incorrect reviews. Want %v, got %v", tt.want, repo.reviews)
+			}
+		})
+	}
+}
+
+func TestReviewCardLeech(t *testing.T) {
+	tests := []struct {
+		name        string
+		group       string
+		card        Card
+		grade       Grade
+		grades      []Grade
+		wantLapses  int
+		wantLeech   bool
+		wantLeeches map[string]bool
+	}{
+		{
+			name:        "New Card Again",
+			group:       "Group",
+			card:        Card{Title: "New"},
+			grade:       Again,
+			wantLapses:  0,
+			wantLeech:   false,
+			wantLeeches: nil,
+		},
+		{
+			name:        "New Card Again Repeatedly",
+			group:       "Group",
+			card:        Card{Title: "New"},
+			grades:      []Grade{Again, Again, Again, Again, Again, Again, Again, Again, Again},
+			wantLapses:  0,
+			wantLeech:   false,
+			wantLeeches: nil,
+		},
+		{
+			name:        "Below Threshold",
+			group:       "Group",
+			card:        Card{Title: "Mature"},
+			grade:       Again,
+			wantLapses:  1,
+			wantLeech:   false,
+			wantLeeches: nil,
+		},
+		{
+			name:        "Recalled",
+			group:       "Group",
+			card:        Card{Title: "Lapsed"},
+			grade:       Hard,
+			wantLapses:  7,
+			wantLeech:   false,
+			wantLeeches: nil,
+		},
+		{
+			name:        "Relearning Again",
+			group:       "Group",
+			card:        Card{Title: "Relearning"},
+			grade:       Again,
+			wantLapses:  7,
+			wantLeech:   false,
+			wantLeeches: nil,
+		},
+		{
+			name:        "Relearned Then Again",
+			group:       "Group",
+			card:        Card{Title: "Relearning"},
+			grades:      []Grade{Good, Again},
+			wantLapses:  8,
+			wantLeech:   true,
+			wantLeeches: map[string]bool{"Group.Relearning": false},
+		},
+		{
+			name:        "Mature Again Twice",
+			group:       "Group",
+			card:        Card{Title: "Mature"},
+			grades:      []Grade{Again, Again},
+			wantLapses:  1,
+			wantLeech:   false,
+			wantLeeches: nil,
+		},
+		{
+			name:        "Crammed",
+			group:       "Group",
+			card:        Card{Title: "Lapsed", Cram: true},
+			grade:       Again,
+			wantLapses:  7,
+			wantLeech:   false,
+			wantLeeches: nil,
+		},
+		{
+			name:        "Default Threshold",
+			group:       "Group",
+			card:        Card{Title: "Lapsed"},
+			grade:       Again,
+			wantLapses:  8,
+			wantLeech:   true,
+			wantLeeches: map[string]bool{"Group.Lapsed": false},
+		},
+		{
+			name:        "Subgroup Threshold And Suspend",
+			group:       "Leech.SubGroup",
+			card:        Card{Title: "Lapsed"},
+			grade:       Again,
+			wantLapses:  3,
+			wantLeech:   true,
+			wantLeeches: map[string]bool{"Leech.SubGroup.Lapsed": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepositoryStubWithCards()
+			rs := New(repo, &clockStub{})
+			grades := tt.grades
+			if grades == nil {
+				grades = []Grade{tt.grade}
+			}
+			var got Card
+			for _, g := range grades {
+				var err error
+				got, err = rs.ReviewCard(tt.group, tt.card, g)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+
+			if got.State.Lapses != tt.wantLapses {
+				t.Errorf("Incorrect lapses. Want %v, got %v", tt.wantLapses, got.State.Lapses)
+			}
+			if got.Leech != tt.wantLeech {
+				t.Errorf("Incorrect leech. Want %v, got %v", tt.wantLeech, got.Leech)
+			}
+			if !reflect.DeepEqual(tt.wantLeeches, repo.leeches) {
+				t.Errorf("Incorrect leeches. Want %v, got %v", tt.wantLeeches, repo.leeches)
+			}
+		})
+	}
+}
+
+func TestParseGrade(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Grade
+		wantErr error
+	}{
+		{name: "Again", s: "again", want: Again, wantErr: nil},
+		{name: "Hard", s: "hard", want: Hard, wantErr: nil},
+		{name: "Good", s: "Good", want: Good, wantErr: nil},
+		{name: "Easy", s: "EASY", want: Easy, wantErr: nil},
+		{name: "Invalid", s: "meh", want: 0, wantErr: ErrInvalidGrade},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseGrade(tt.s)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Incorrect grade. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package reviewing
+
+import "time"
+
+type Settings struct {
+	Scheduler string
+	Weights   []float64
+	Retention float64
+	Boxes     []int
+	// LeechThreshold is the number of lapses that make a card a leech, and
+	// LeechAction what is done to it then.
+	LeechThreshold int
+	LeechAction    string
+	// Target is the date every card should be reviewed TargetReviews
+	// more times by.
+	Target        time.Time
+	TargetReviews int
+}
+
+// Validate checks the scheduler and leech settings of a group the way
+// reviews use them, for the configuring service to reject any that reviewing
+// would.
+func Validate(s Settings) error {
+	if _, err := NewScheduler(Settings{Scheduler: s.Scheduler}); err != nil {
+		return err
+	}
+	// Weights, retention and boxes may be set for a scheduler inherited from
+	// a parent group, so they are checked whichever scheduler is selected.
+	if _, err := NewFSRS(s.Weights, s.Retention); err != nil {
+		return err
+	}
+	if _, err := NewLeitner(s.Boxes); err != nil {
+		return err
+	}
+	switch s.LeechAction {
+	case "", LeechTag, LeechSuspend:
+		return nil
+	}
+	return ErrUnknownLeechAction
+}
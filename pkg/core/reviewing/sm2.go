@@ -0,0 +1,54 @@
+package reviewing
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	defaultEaseFactor = 2.5
+	minEaseFactor     = 1.3
+)
+
+type sm2 struct{}
+
+func NewSM2() *sm2 {
+	return &sm2{}
+}
+
+// quality maps a grade onto the 0-5 response scale used by SM-2.
+func quality(g Grade) float64 {
+	return float64(g) + 2
+}
+
+// Schedule computes the card's next state using the SuperMemo-2 algorithm.
+func (s *sm2) Schedule(st State, g Grade, now time.Time) (State, time.Time) {
+	if st.EaseFactor == 0 {
+		st.EaseFactor = defaultEaseFactor
+	}
+
+	q := quality(g)
+	if q < 3 {
+		// A lapse restarts the repetitions without changing the ease factor.
+		st.Repetitions = 0
+		st.Interval = 1
+	} else {
+		switch st.Repetitions {
+		case 0:
+			st.Interval = 1
+		case 1:
+			st.Interval = 6
+		default:
+			st.Interval = int(math.Round(float64(st.Interval) * st.EaseFactor))
+		}
+		st.Repetitions++
+
+		st.EaseFactor += 0.1 - (5-q)*(0.08+(5-q)*0.02)
+		if st.EaseFactor < minEaseFactor {
+			st.EaseFactor = minEaseFactor
+		}
+	}
+
+	st.Reviewed = now
+	return st, now.AddDate(0, 0, st.Interval)
+}
@@ -0,0 +1,47 @@
+package reviewing
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultTargetReviews is how many more times a card is reviewed before its
+// group's target date when the group does not say.
+const DefaultTargetReviews = 1
+
+// capInterval shortens the interval so that the card can still be reviewed
+// the target number of times before the group's target date. Intervals are
+// left alone once the date has passed.
+func (s Settings) capInterval(st State, due, now time.Time) (State, time.Time) {
+	if s.Target.IsZero() {
+		return st, due
+	}
+	days := DaysUntil(now, s.Target)
+	if days <= 0 {
+		return st, due
+	}
+
+	reviews := s.TargetReviews
+	if reviews == 0 {
+		reviews = DefaultTargetReviews
+	}
+	max := days / reviews
+	if max < 1 {
+		max = 1
+	}
+	if st.Interval > max {
+		st.Interval = max
+		due = now.AddDate(0, 0, max)
+	}
+	return st, due
+}
+
+// DaysUntil returns the number of calendar days from now until t, negative
+// once t has passed.
+func DaysUntil(now, t time.Time) int {
+	y, m, d := now.Date()
+	from := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	y, m, d = t.Date()
+	to := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
@@ -0,0 +1,35 @@
+package revising
+
+import "time"
+
+type Card struct {
+	ID    string
+	Title string
+}
+
+// Revision is the content a card had from a point in time, numbered from 1
+// for the oldest.
+type Revision struct {
+	Number  int
+	Desc    string
+	Answers []string
+	Time    time.Time
+	// Current is set for the content the card has now.
+	Current bool
+}
+
+type LineOp int
+
+const (
+	// Equal lines are in both revisions.
+	Equal LineOp = iota
+	// Insert lines are only in the newer revision.
+	Insert
+	// Delete lines are only in the older revision.
+	Delete
+)
+
+type Line struct {
+	Op   LineOp
+	Text string
+}
@@ -0,0 +1,52 @@
+package revising
+
+import "strings"
+
+// Lines returns the revision's description, split into lines, followed by
+// a line per alternative answer.
+func (r Revision) Lines() []string {
+	lines := strings.Split(r.Desc, "\n")
+	for _, a := range r.Answers {
+		lines = append(lines, "(or "+a+")")
+	}
+	return lines
+}
+
+// DiffLines returns the lines that turn a into b, keeping the longest run
+// of lines common to both.
+func DiffLines(a, b []string) []Line {
+	// l[i][j] is the length of the longest common subsequence of a[i:] and
+	// b[j:].
+	l := make([][]int, len(a)+1)
+	for i := range l {
+		l[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				l[i][j] = l[i+1][j+1] + 1
+			} else if l[i+1][j] >= l[i][j+1] {
+				l[i][j] = l[i+1][j]
+			} else {
+				l[i][j] = l[i][j+1]
+			}
+		}
+	}
+
+	var lines []Line
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, Line{Equal, a[i]})
+			i, j = i+1, j+1
+		case j == len(b) || i < len(a) && l[i+1][j] >= l[i][j+1]:
+			lines = append(lines, Line{Delete, a[i]})
+			i++
+		default:
+			lines = append(lines, Line{Insert, b[j]})
+			j++
+		}
+	}
+	return lines
+}
@@ -0,0 +1,127 @@
+package revising
+
+import (
+	"errors"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/updating"
+	"github.com/jmcveigh55/flash/pkg/storage"
+)
+
+var (
+	ErrCardEmptyTitle   error = errors.New("card has an empty title")
+	ErrCardNotFound     error = errors.New("card not found")
+	ErrRevisionNotFound error = errors.New("revision not found")
+	ErrCurrentRevision  error = errors.New("revision is the current one")
+)
+
+// Service shows how a card's content has changed over its revisions, and
+// brings back an earlier one.
+type Service interface {
+	GetHistory(string, Card) ([]Revision, error)
+	Diff(string, Card, int) ([]Line, error)
+	Revert(string, Card, int) error
+}
+
+type service struct {
+	g getting.Service
+	u updating.Service
+}
+
+func New(g getting.Service, u updating.Service) *service {
+	return &service{g, u}
+}
+
+// GetHistory returns the card's revisions, oldest first, ending with the
+// current one.
+func (s *service) GetHistory(g string, c Card) ([]Revision, error) {
+	card, err := s.findCard(g, c)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []Revision
+	for i, r := range card.Revisions {
+		history = append(history, Revision{
+			Number:  i + 1,
+			Desc:    r.Desc,
+			Answers: r.Answers,
+			Time:    r.Time,
+		})
+	}
+	return append(history, Revision{
+		Number:  len(history) + 1,
+		Desc:    card.Desc,
+		Answers: card.Answers,
+		Time:    card.Updated,
+		Current: true,
+	}), nil
+}
+
+// Diff returns the lines changed from the revision to the current content.
+// The description is followed by a line per alternative answer.
+func (s *service) Diff(g string, c Card, n int) ([]Line, error) {
+	history, err := s.GetHistory(g, c)
+	if err != nil {
+		return nil, err
+	}
+	if n < 1 || n > len(history) {
+		return nil, ErrRevisionNotFound
+	}
+	return DiffLines(history[n-1].Lines(), history[len(history)-1].Lines()), nil
+}
+
+// Revert gives the card the content of the revision back. The content it
+// had is kept as a revision, like any other update.
+func (s *service) Revert(g string, c Card, n int) error {
+	history, err := s.GetHistory(g, c)
+	if err != nil {
+		return err
+	}
+	if n < 1 || n > len(history) {
+		return ErrRevisionNotFound
+	}
+	if n == len(history) {
+		return ErrCurrentRevision
+	}
+
+	r := history[n-1]
+	answers := r.Answers
+	if answers == nil {
+		// A nil list would keep the current answers.
+		answers = []string{}
+	}
+	return s.u.UpdateCard(g, updating.Card{
+		ID:      c.ID,
+		Title:   c.Title,
+		Desc:    r.Desc,
+		Answers: answers,
+	})
+}
+
+// findCard finds the card by its ID, wherever it is, or by its title in the
+// group.
+func (s *service) findCard(g string, c Card) (getting.Card, error) {
+	if c.ID == "" && c.Title == "" {
+		return getting.Card{}, ErrCardEmptyTitle
+	}
+
+	var cards []getting.Card
+	var err error
+	if c.ID != "" {
+		cards, err = s.g.GetAllCards("", getting.All)
+	} else {
+		cards, err = s.g.GetCards(g, getting.All)
+	}
+	if err != nil {
+		return getting.Card{}, err
+	}
+
+	path := storage.JoinPath(g, c.Title)
+	for _, card := range cards {
+		if (c.ID != "" && card.ID == c.ID) || (c.ID == "" && card.Title == path) {
+			return card, nil
+		}
+	}
+	return getting.Card{}, ErrCardNotFound
+}
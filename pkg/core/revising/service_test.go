@@ -0,0 +1,286 @@
+package revising
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/updating"
+)
+
+var (
+	errGroupNotFound error = errors.New("group not found")
+
+	day1 = time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	day2 = time.Date(2022, 11, 2, 0, 0, 0, 0, time.UTC)
+	day3 = time.Date(2022, 11, 3, 0, 0, 0, 0, time.UTC)
+)
+
+// storeStub is both the getting and the updating service, keeping a
+// revision of each update like the repositories do.
+type storeStub struct {
+	cards []getting.Card
+	now   time.Time
+}
+
+func newStoreStubWithCards() *storeStub {
+	return &storeStub{
+		cards: []getting.Card{
+			{ID: "1", Title: "Group.Subject1", Desc: "Value1", Updated: day1},
+			{
+				ID: "2", Title: "Group.Subject2", Desc: "line1\nline3", Answers: []string{"other"}, Updated: day3,
+				Revisions: []getting.Revision{
+					{Desc: "line1\nline2", Time: day1},
+					{Desc: "line1\nline2\nline3", Answers: []string{"other"}, Time: day2},
+				},
+			},
+		},
+		now: day3.Add(time.Hour),
+	}
+}
+
+func (s *storeStub) GetCards(g string, _ getting.Filter) ([]getting.Card, error) {
+	if g != "Group" {
+		return nil, errGroupNotFound
+	}
+	return s.cards, nil
+}
+
+func (s *storeStub) GetAllCards(string, getting.Filter) ([]getting.Card, error) {
+	return s.cards, nil
+}
+
+func (s *storeStub) GetCardsInBox(string, int, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (s *storeStub) GetLeeches(string) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (s *storeStub) UpdateCard(g string, c updating.Card) error {
+	for i, card := range s.cards {
+		if (c.ID != "" && card.ID == c.ID) || (c.ID == "" && card.Title == g+"."+c.Title) {
+			s.cards[i].Revisions = append(card.Revisions, getting.Revision{
+				Desc: card.Desc, Answers: card.Answers, Time: card.Updated,
+			})
+			s.cards[i].Desc = c.Desc
+			if c.Answers != nil {
+				s.cards[i].Answers = c.Answers
+			}
+			s.cards[i].Updated = s.now
+			return nil
+		}
+	}
+	return errors.New("card not found")
+}
+
+func TestGetHistory(t *testing.T) {
+	tests := []struct {
+		name  string
+		group string
+		card  Card
+		want  []Revision
+		err   error
+	}{
+		{
+			name:  "no revisions",
+			group: "Group",
+			card:  Card{Title: "Subject1"},
+			want:  []Revision{{Number: 1, Desc: "Value1", Time: day1, Current: true}},
+		},
+		{
+			name: "revisions by ID",
+			card: Card{ID: "2"},
+			want: []Revision{
+				{Number: 1, Desc: "line1\nline2", Time: day1},
+				{Number: 2, Desc: "line1\nline2\nline3", Answers: []string{"other"}, Time: day2},
+				{Number: 3, Desc: "line1\nline3", Answers: []string{"other"}, Time: day3, Current: true},
+			},
+		},
+		{
+			name:  "empty title",
+			group: "Group",
+			err:   ErrCardEmptyTitle,
+		},
+		{
+			name:  "card not found",
+			group: "Group",
+			card:  Card{Title: "Subject3"},
+			err:   ErrCardNotFound,
+		},
+		{
+			name:  "group not found",
+			group: "Other",
+			card:  Card{Title: "Subject1"},
+			err:   errGroupNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := newStoreStubWithCards()
+			got, err := New(store, store).GetHistory(test.group, test.card)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Incorrect error. Want %v, got %v", test.err, err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Incorrect history. Want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		revision int
+		want     []Line
+		err      error
+	}{
+		{
+			name:     "oldest",
+			revision: 1,
+			want: []Line{
+				{Equal, "line1"},
+				{Delete, "line2"},
+				{Insert, "line3"},
+				{Insert, "(or other)"},
+			},
+		},
+		{
+			name:     "previous",
+			revision: 2,
+			want: []Line{
+				{Equal, "line1"},
+				{Delete, "line2"},
+				{Equal, "line3"},
+				{Equal, "(or other)"},
+			},
+		},
+		{
+			name:     "current",
+			revision: 3,
+			want: []Line{
+				{Equal, "line1"},
+				{Equal, "line3"},
+				{Equal, "(or other)"},
+			},
+		},
+		{
+			name:     "revision not found",
+			revision: 4,
+			err:      ErrRevisionNotFound,
+		},
+		{
+			name:     "revision zero",
+			revision: 0,
+			err:      ErrRevisionNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := newStoreStubWithCards()
+			got, err := New(store, store).Diff("Group", Card{Title: "Subject2"}, test.revision)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Incorrect error. Want %v, got %v", test.err, err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Incorrect diff. Want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRevert(t *testing.T) {
+	tests := []struct {
+		name     string
+		revision int
+		want     []Revision
+		err      error
+	}{
+		{
+			name:     "reverted",
+			revision: 1,
+			want: []Revision{
+				{Number: 1, Desc: "line1\nline2", Time: day1},
+				{Number: 2, Desc: "line1\nline2\nline3", Answers: []string{"other"}, Time: day2},
+				{Number: 3, Desc: "line1\nline3", Answers: []string{"other"}, Time: day3},
+				{Number: 4, Desc: "line1\nline2", Answers: []string{}, Time: day3.Add(time.Hour), Current: true},
+			},
+		},
+		{
+			name:     "current",
+			revision: 3,
+			err:      ErrCurrentRevision,
+		},
+		{
+			name:     "revision not found",
+			revision: 4,
+			err:      ErrRevisionNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := newStoreStubWithCards()
+			s := New(store, store)
+			err := s.Revert("Group", Card{Title: "Subject2"}, test.revision)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Incorrect error. Want %v, got %v", test.err, err)
+			}
+			if test.err != nil {
+				return
+			}
+			got, _ := s.GetHistory("Group", Card{Title: "Subject2"})
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Incorrect history. Want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want []Line
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "added",
+			b:    []string{"a"},
+			want: []Line{{Insert, "a"}},
+		},
+		{
+			name: "removed",
+			a:    []string{"a"},
+			want: []Line{{Delete, "a"}},
+		},
+		{
+			name: "changed",
+			a:    []string{"a", "b", "c"},
+			b:    []string{"a", "x", "c", "d"},
+			want: []Line{{Equal, "a"}, {Delete, "b"}, {Insert, "x"}, {Equal, "c"}, {Insert, "d"}},
+		},
+		{
+			name: "moved",
+			a:    []string{"a", "b"},
+			b:    []string{"b", "a"},
+			want: []Line{{Delete, "a"}, {Equal, "b"}, {Insert, "a"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := DiffLines(test.a, test.b); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Incorrect diff. Want %v, got %v", test.want, got)
+			}
+		})
+	}
+}
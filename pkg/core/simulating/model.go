@@ -0,0 +1,74 @@
+package simulating
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+)
+
+// Model is the probability model of how faces are recalled during a
+// simulation.
+type Model struct {
+	// Retention is the chance of recalling a face on the day it is due.
+	// Recall decays exponentially with the days since the face was last
+	// reviewed, relative to its interval.
+	Retention float64
+	// NewRecall is the chance of recalling a face studied for the first
+	// time.
+	NewRecall float64
+	// Hard and Easy are the shares of recalled faces graded hard and easy.
+	// The rest are graded good.
+	Hard float64
+	Easy float64
+	// AnswerTime is how long each review takes.
+	AnswerTime time.Duration
+}
+
+var DefaultModel = Model{
+	Retention:  0.9,
+	NewRecall:  0.7,
+	Hard:       0.15,
+	Easy:       0.1,
+	AnswerTime: 8 * time.Second,
+}
+
+func (m Model) valid() bool {
+	for _, p := range []float64{m.Retention, m.NewRecall, m.Hard, m.Easy} {
+		if p < 0 || p > 1 {
+			return false
+		}
+	}
+	return m.Hard+m.Easy <= 1 && m.AnswerTime >= 0
+}
+
+// recall returns the chance of recalling a face at the time. New faces have
+// never been scheduled.
+func (m Model) recall(c reviewing.Card, now time.Time) float64 {
+	if c.Due.IsZero() {
+		return m.NewRecall
+	}
+	last := c.State.Reviewed
+	if last.IsZero() {
+		last = c.Due.AddDate(0, 0, -c.State.Interval)
+	}
+	interval := math.Max(float64(c.State.Interval), 1)
+	elapsed := math.Max(now.Sub(last).Hours()/24, 0)
+	return math.Pow(m.Retention, elapsed/interval)
+}
+
+// grade draws the grade given to a face recalled with the chance p.
+func (m Model) grade(p float64, rnd *rand.Rand) reviewing.Grade {
+	if rnd.Float64() >= p {
+		return reviewing.Again
+	}
+	switch r := rnd.Float64(); {
+	case r < m.Hard:
+		return reviewing.Hard
+	case r < m.Hard+m.Easy:
+		return reviewing.Easy
+	default:
+		return reviewing.Good
+	}
+}
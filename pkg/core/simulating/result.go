@@ -0,0 +1,31 @@
+package simulating
+
+import "time"
+
+type Result struct {
+	Days []Day
+}
+
+// Day is what was studied on a day of a simulation.
+type Day struct {
+	Day       time.Time
+	New       int
+	Reviews   int
+	Forgotten int
+	Time      time.Duration
+	// Retention is the predicted share of the faces seen so far that would
+	// be recalled at the start of the day.
+	Retention float64
+}
+
+// Total sums the faces studied and time spent over the simulation.
+func (r Result) Total() Day {
+	var total Day
+	for _, d := range r.Days {
+		total.New += d.New
+		total.Reviews += d.Reviews
+		total.Forgotten += d.Forgotten
+		total.Time += d.Time
+	}
+	return total
+}
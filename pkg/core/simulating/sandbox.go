@@ -0,0 +1,105 @@
+package simulating
+
+import (
+	"errors"
+
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/storage"
+)
+
+var errNotCopied error = errors.New("card was not copied into the simulation")
+
+// sandbox is the reviewing.Repository a simulation reviews against. It
+// holds copies of the faces' schedules so the real repository is only ever
+// read from.
+type sandbox struct {
+	r Repository
+	// group's own config is overridden by config.
+	group     string
+	config    configuring.Config
+	cards     map[string]reviewing.Card
+	suspended map[string]bool
+}
+
+func newSandbox(r Repository, g string, c configuring.Config) *sandbox {
+	return &sandbox{
+		r:         r,
+		group:     g,
+		config:    c,
+		cards:     map[string]reviewing.Card{},
+		suspended: map[string]bool{},
+	}
+}
+
+// copy takes a copy of the face's schedule from the real repository.
+func (s *sandbox) copy(g string, c reviewing.Card) error {
+	card, err := s.r.GetSchedule(g, c)
+	if err != nil {
+		return err
+	}
+	s.cards[faceKey(g, c)] = card
+	return nil
+}
+
+func (s *sandbox) card(f face) reviewing.Card {
+	return s.cards[faceKey(f.group, f.card)]
+}
+
+func (s *sandbox) GetSchedule(g string, c reviewing.Card) (reviewing.Card, error) {
+	card, ok := s.cards[faceKey(g, c)]
+	if !ok {
+		return reviewing.Card{}, errNotCopied
+	}
+	card.Duration = c.Duration
+	return card, nil
+}
+
+func (s *sandbox) UpdateSchedule(g string, c reviewing.Card) error {
+	s.cards[faceKey(g, c)] = c
+	return nil
+}
+
+// GetConfig returns the group's own config, with the overrides applied to
+// the simulated group.
+func (s *sandbox) GetConfig(g string) (configuring.Config, error) {
+	c, err := s.r.GetConfig(g)
+	if err != nil || g != s.group {
+		return c, err
+	}
+	return s.config.Inherit(c), nil
+}
+
+func (s *sandbox) GetReviewSettings(g string) (reviewing.Settings, error) {
+	c, err := configuring.Resolve(s.GetConfig, g)
+	if err != nil {
+		return reviewing.Settings{}, err
+	}
+	return reviewing.Settings{
+		Scheduler:      c.Scheduler,
+		Weights:        c.Weights,
+		Retention:      c.Retention,
+		Boxes:          c.Boxes,
+		LeechThreshold: c.LeechThreshold,
+		LeechAction:    c.LeechAction,
+		Target:         c.Target,
+		TargetReviews:  c.TargetReviews,
+	}, nil
+}
+
+// AddReview leaves the review log alone, the simulation counts its own
+// reviews.
+func (s *sandbox) AddReview(reviewing.Review) error {
+	return nil
+}
+
+func (s *sandbox) MarkLeech(g string, c reviewing.Card, suspend bool) error {
+	if suspend {
+		s.suspended[storage.JoinPath(g, c.Title)] = true
+	}
+	return nil
+}
+
+func faceKey(g string, c reviewing.Card) string {
+	return storage.JoinPath(g, c.Title) + "/" + c.Face
+}
@@ -0,0 +1,232 @@
+package simulating
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/storage"
+)
+
+var (
+	ErrInvalidDays  error = errors.New("a simulation needs at least one day")
+	ErrInvalidModel error = errors.New("recall chances must be between 0 and 1")
+)
+
+type Service interface {
+	Simulate(string, Options) (*Result, error)
+}
+
+// Repository is only read from. Reviews made during a simulation are kept
+// in a sandbox.
+type Repository interface {
+	GetSchedule(string, reviewing.Card) (reviewing.Card, error)
+	GetConfig(string) (configuring.Config, error)
+}
+
+type service struct {
+	g     getting.Service
+	r     Repository
+	clock storage.Clock
+}
+
+func New(g getting.Service, r Repository, c storage.Clock) *service {
+	return &service{g, r, c}
+}
+
+type Options struct {
+	Days  int
+	Model Model
+	// Config overrides the group's own config, leaving the rest inherited.
+	Config configuring.Config
+	// Seed seeds the draws of the model, or the current time if 0.
+	Seed int64
+}
+
+// face is a face of a card under the group, reviewed by group and title.
+type face struct {
+	group string
+	card  reviewing.Card
+}
+
+// Simulate studies the unsuspended cards under the group once a day for the
+// number of days, as the group's scheduler and daily limits would have them
+// studied, grading each face by the model. Due faces are reviewed first,
+// then new faces in turn.
+func (s *service) Simulate(g string, o Options) (*Result, error) {
+	if o.Days < 1 {
+		return nil, ErrInvalidDays
+	}
+	if !o.Model.valid() {
+		return nil, ErrInvalidModel
+	}
+
+	cards, err := s.g.GetAllCards(g, getting.Unsuspended)
+	if err != nil {
+		return nil, err
+	}
+	box := newSandbox(s.r, g, o.Config)
+	lim := &limiter{box, map[string]configuring.Config{}}
+
+	var faces []face
+	for _, c := range cards {
+		group, title := storage.SplitPath(c.Title)
+		for _, f := range c.Faces() {
+			card := reviewing.Card{Title: title, Face: f}
+			if err := box.copy(group, card); err != nil {
+				return nil, err
+			}
+			faces = append(faces, face{group, card})
+		}
+	}
+
+	now := s.clock.Now()
+	seed := o.Seed
+	if seed == 0 {
+		seed = now.UnixNano()
+	}
+	rnd := rand.New(rand.NewSource(seed))
+	clock := storage.NewFakeClock(now)
+	rv := reviewing.New(box, clock)
+
+	result := &Result{}
+	for d := 0; d < o.Days; d++ {
+		now := clock.Now()
+		day := Day{Day: startOfDay(now), Retention: retention(box, faces, o.Model, now)}
+
+		var due, unseen []face
+		for _, f := range faces {
+			c := box.card(f)
+			switch {
+			case box.suspended[storage.JoinPath(f.group, f.card.Title)]:
+			case c.Due.IsZero():
+				unseen = append(unseen, f)
+			case !c.Due.After(now):
+				due = append(due, f)
+			}
+		}
+		sort.SliceStable(due, func(i, j int) bool {
+			return box.card(due[i]).Due.Before(box.card(due[j]).Due)
+		})
+
+		due, err := lim.filter(due, false)
+		if err != nil {
+			return nil, err
+		}
+		unseen, err = lim.filter(unseen, true)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range due {
+			forgot, err := s.review(rv, box, f, o.Model, rnd, now)
+			if err != nil {
+				return nil, err
+			}
+			day.Reviews++
+			if forgot {
+				day.Forgotten++
+			}
+		}
+		for _, f := range unseen {
+			if _, err := s.review(rv, box, f, o.Model, rnd, now); err != nil {
+				return nil, err
+			}
+			day.New++
+		}
+		day.Time = time.Duration(day.New+day.Reviews) * o.Model.AnswerTime
+
+		result.Days = append(result.Days, day)
+		clock.AddDate(1)
+	}
+	return result, nil
+}
+
+// review grades the face as the model would, and reports whether it was
+// forgotten.
+func (s *service) review(rv reviewing.Service, box *sandbox, f face, m Model, rnd *rand.Rand, now time.Time) (bool, error) {
+	gr := m.grade(m.recall(box.card(f), now), rnd)
+	card := f.card
+	card.Duration = m.AnswerTime
+	_, err := rv.ReviewCard(f.group, card, gr)
+	return gr == reviewing.Again, err
+}
+
+// limiter applies the daily limits of the groups to the faces studied on a
+// day. As when studying, each face counts against the closest group, from
+// its own group up, that limits faces like it.
+type limiter struct {
+	box     *sandbox
+	configs map[string]configuring.Config
+}
+
+// filter keeps the new or due faces that fit in their groups' limits, in
+// order.
+func (l *limiter) filter(faces []face, isNew bool) ([]face, error) {
+	taken := map[string]int{}
+	kept := []face{}
+	for _, f := range faces {
+		g, max, err := l.limit(f.group, isNew)
+		if err != nil {
+			return nil, err
+		}
+		if max != configuring.Unlimited {
+			if taken[g] >= max {
+				continue
+			}
+			taken[g]++
+		}
+		kept = append(kept, f)
+	}
+	return kept, nil
+}
+
+// limit returns the closest group, from g up, that limits new faces or
+// reviews, and its limit.
+func (l *limiter) limit(g string, isNew bool) (string, int, error) {
+	for _, g := range configuring.Lineage(g) {
+		c, ok := l.configs[g]
+		if !ok {
+			var err error
+			if c, err = l.box.GetConfig(g); err != nil {
+				return "", 0, err
+			}
+			l.configs[g] = c
+		}
+		n := c.ReviewsPerDay
+		if isNew {
+			n = c.NewPerDay
+		}
+		if n != 0 {
+			return g, n, nil
+		}
+	}
+	return "", configuring.Unlimited, nil
+}
+
+// retention averages the chance of recalling each face seen so far.
+func retention(box *sandbox, faces []face, m Model, now time.Time) float64 {
+	total := 0.0
+	n := 0
+	for _, f := range faces {
+		c := box.card(f)
+		if c.Due.IsZero() {
+			continue
+		}
+		total += m.recall(c, now)
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return total / float64(n)
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
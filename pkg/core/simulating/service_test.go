@@ -0,0 +1,280 @@
+package simulating
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+)
+
+var (
+	errGroupNotFound error = errors.New("group not found")
+	errCardNotFound  error = errors.New("card not found")
+)
+
+var now = time.Date(2022, time.November, 1, 12, 0, 0, 0, time.UTC)
+
+type clockStub struct{}
+
+func (c *clockStub) Now() time.Time {
+	return now
+}
+
+type gettingStub struct{}
+
+func (g *gettingStub) GetCards(string, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (g *gettingStub) GetAllCards(group string, _ getting.Filter) ([]getting.Card, error) {
+	if group != "Group" {
+		return []getting.Card{}, errGroupNotFound
+	}
+	return []getting.Card{
+		{Title: "Group.New1"},
+		{Title: "Group.New2"},
+		{Title: "Group.Due"},
+		{Title: "Group.Later"},
+	}, nil
+}
+
+func (g *gettingStub) GetCardsInBox(string, int, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (g *gettingStub) GetLeeches(string) ([]getting.Card, error) {
+	return nil, nil
+}
+
+// repositoryStub only has the read methods of a real repository, so a
+// simulation cannot change it.
+type repositoryStub struct {
+	configs map[string]configuring.Config
+}
+
+var schedules = map[string]reviewing.Card{
+	"Group.Due": {
+		Due:   now,
+		State: reviewing.State{Interval: 6, EaseFactor: 2.5, Repetitions: 2, Reviewed: now.AddDate(0, 0, -6)},
+	},
+	"Group.Later": {
+		Due:   now.AddDate(0, 0, 10),
+		State: reviewing.State{Interval: 15, EaseFactor: 2.5, Repetitions: 3, Reviewed: now.AddDate(0, 0, -5)},
+	},
+}
+
+func (r *repositoryStub) GetSchedule(g string, c reviewing.Card) (reviewing.Card, error) {
+	if g != "Group" && g != "Group.Sub" {
+		return reviewing.Card{}, errCardNotFound
+	}
+	card := schedules[g+"."+c.Title]
+	card.Title = c.Title
+	card.Face = c.Face
+	return card, nil
+}
+
+func (r *repositoryStub) GetConfig(g string) (configuring.Config, error) {
+	return r.configs[g], nil
+}
+
+func TestSimulate(t *testing.T) {
+	recalled := Model{Retention: 1, NewRecall: 1, AnswerTime: 10 * time.Second}
+	day := func(d int) time.Time {
+		return time.Date(2022, time.November, 1+d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		group   string
+		configs map[string]configuring.Config
+		options Options
+		want    *Result
+		wantErr error
+	}{
+		{
+			name:    "Normal",
+			group:   "Group",
+			options: Options{Days: 3, Model: recalled},
+			want: &Result{Days: []Day{
+				{Day: day(0), New: 2, Reviews: 1, Time: 30 * time.Second, Retention: 1},
+				{Day: day(1), Reviews: 2, Time: 20 * time.Second, Retention: 1},
+				{Day: day(2), Retention: 1},
+			}},
+			wantErr: nil,
+		},
+		{
+			name:    "Inherited New Limit",
+			group:   "Group",
+			configs: map[string]configuring.Config{"": {NewPerDay: 1}},
+			options: Options{Days: 3, Model: recalled},
+			want: &Result{Days: []Day{
+				{Day: day(0), New: 1, Reviews: 1, Time: 20 * time.Second, Retention: 1},
+				{Day: day(1), New: 1, Reviews: 1, Time: 20 * time.Second, Retention: 1},
+				{Day: day(2), Reviews: 1, Time: 10 * time.Second, Retention: 1},
+			}},
+			wantErr: nil,
+		},
+		{
+			name:    "Overridden Limit",
+			group:   "Group",
+			configs: map[string]configuring.Config{"": {NewPerDay: 1}},
+			options: Options{Days: 1, Model: recalled, Config: configuring.Config{NewPerDay: configuring.Unlimited}},
+			want: &Result{Days: []Day{
+				{Day: day(0), New: 2, Reviews: 1, Time: 30 * time.Second, Retention: 1},
+			}},
+			wantErr: nil,
+		},
+		{
+			name:    "Forgotten",
+			group:   "Group",
+			options: Options{Days: 2, Model: Model{}},
+			want: &Result{Days: []Day{
+				{Day: day(0), New: 2, Reviews: 1, Forgotten: 1},
+				{Day: day(1), Reviews: 3, Forgotten: 3},
+			}},
+			wantErr: nil,
+		},
+		{
+			name:    "Unknown Scheduler",
+			group:   "Group",
+			options: Options{Days: 1, Model: recalled, Config: configuring.Config{Scheduler: "unknown"}},
+			want:    nil,
+			wantErr: reviewing.ErrUnknownScheduler,
+		},
+		{
+			name:    "Invalid Days",
+			group:   "Group",
+			options: Options{Days: 0, Model: recalled},
+			want:    nil,
+			wantErr: ErrInvalidDays,
+		},
+		{
+			name:    "Invalid Model",
+			group:   "Group",
+			options: Options{Days: 1, Model: Model{Retention: 2}},
+			want:    nil,
+			wantErr: ErrInvalidModel,
+		},
+		{
+			name:    "Group Not Found",
+			group:   "NotFound",
+			options: Options{Days: 1, Model: recalled},
+			want:    nil,
+			wantErr: errGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.Seed = 1
+			ss := New(&gettingStub{}, &repositoryStub{tt.configs}, &clockStub{})
+			got, err := ss.Simulate(tt.group, tt.options)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect result. Want %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+// subgroupStub has new cards in a group and in its subgroup.
+type subgroupStub struct {
+	gettingStub
+}
+
+func (g *subgroupStub) GetAllCards(string, getting.Filter) ([]getting.Card, error) {
+	return []getting.Card{
+		{Title: "Group.New1"},
+		{Title: "Group.New2"},
+		{Title: "Group.Sub.New1"},
+		{Title: "Group.Sub.New2"},
+		{Title: "Group.Sub.New3"},
+	}, nil
+}
+
+func TestSimulateSubgroupLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs map[string]configuring.Config
+		want    int
+	}{
+		{
+			name:    "Inherited",
+			configs: map[string]configuring.Config{"Group": {NewPerDay: 1}},
+			want:    1,
+		},
+		{
+			name: "Own Limit",
+			configs: map[string]configuring.Config{
+				"Group":     {NewPerDay: 1},
+				"Group.Sub": {NewPerDay: 2},
+			},
+			want: 3,
+		},
+		{
+			name: "Unlimited",
+			configs: map[string]configuring.Config{
+				"Group":     {NewPerDay: 1},
+				"Group.Sub": {NewPerDay: configuring.Unlimited},
+			},
+			want: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := New(&subgroupStub{}, &repositoryStub{tt.configs}, &clockStub{})
+			got, err := ss.Simulate("Group", Options{Days: 1, Model: Model{Retention: 1, NewRecall: 1}, Seed: 1})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if got.Days[0].New != tt.want {
+				t.Errorf("Incorrect new cards. Want %v, got %v", tt.want, got.Days[0].New)
+			}
+		})
+	}
+}
+
+func TestRecall(t *testing.T) {
+	m := Model{Retention: 0.9, NewRecall: 0.5}
+	tests := []struct {
+		name string
+		card reviewing.Card
+		want float64
+	}{
+		{"New", reviewing.Card{}, 0.5},
+		{
+			"Due",
+			reviewing.Card{Due: now, State: reviewing.State{Interval: 4, Reviewed: now.AddDate(0, 0, -4)}},
+			0.9,
+		},
+		{
+			"Overdue",
+			reviewing.Card{Due: now.AddDate(0, 0, -4), State: reviewing.State{Interval: 4, Reviewed: now.AddDate(0, 0, -8)}},
+			0.81,
+		},
+		{
+			"Not Reviewed",
+			reviewing.Card{Due: now.AddDate(0, 0, 2), State: reviewing.State{Interval: 4}},
+			math.Pow(0.9, 0.5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.recall(tt.card, now); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Incorrect recall. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package studying
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/jmcveigh55/flash/pkg/core/cloze"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/storage"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Similarity thresholds used to suggest a grade for a typed answer.
+const (
+	goodSimilarity = 1
+	hardSimilarity = 0.8
+)
+
+// MatchOptions choose which differences are ignored when a typed answer is
+// compared to the expected one.
+type MatchOptions struct {
+	IgnoreCase       bool
+	IgnoreSpace      bool
+	IgnoreDiacritics bool
+}
+
+type EditOp int
+
+const (
+	// Equal text is in both the typed and the expected answer.
+	Equal EditOp = iota
+	// Insert text is in the expected answer but was not typed.
+	Insert
+	// Delete text was typed but is not in the expected answer.
+	Delete
+)
+
+// Edit is a run of characters sharing the same EditOp.
+type Edit struct {
+	Op   EditOp
+	Text string
+}
+
+// Check is the result of comparing a typed answer to a card.
+type Check struct {
+	// Expected is the accepted answer closest to the typed one.
+	Expected   string
+	Similarity float64
+	// Grade is suggested from the similarity and may be overridden.
+	Grade reviewing.Grade
+	// Diff turns the typed answer into the expected one.
+	Diff []Edit
+}
+
+// Accepted returns every answer accepted for the item. The forward face
+// accepts the description or any alternative answer, the reverse face the
+// card's title, a cloze face the text of its deletions and a note face the
+// back of its template.
+func (i Item) Accepted() []string {
+	if _, ok := i.template(); ok {
+		return []string{i.Answer()}
+	}
+	if i.Card.Cloze {
+		return []string{strings.Join(cloze.Answers(i.Card.Desc, i.Face), ", ")}
+	}
+	if i.Face == getting.Reverse {
+		_, title := storage.SplitPath(i.Card.Title)
+		return []string{title}
+	}
+	return append([]string{i.Card.Desc}, i.Card.Answers...)
+}
+
+// Check compares a typed answer to the item's accepted answers using the
+// normalized edit distance, keeping the closest one.
+func (i Item) Check(answer string, o MatchOptions) Check {
+	var best Check
+	for n, a := range i.Accepted() {
+		c := compare(answer, a, o)
+		if n == 0 || c.Similarity > best.Similarity {
+			best = c
+		}
+	}
+	return best
+}
+
+// SuggestGrade maps the similarity of a typed answer to a grade.
+func SuggestGrade(similarity float64) reviewing.Grade {
+	switch {
+	case similarity >= goodSimilarity:
+		return reviewing.Good
+	case similarity >= hardSimilarity:
+		return reviewing.Hard
+	default:
+		return reviewing.Again
+	}
+}
+
+func compare(answer, expected string, o MatchOptions) Check {
+	a := []rune(prepare(answer, o))
+	b := []rune(prepare(expected, o))
+
+	// d[i][j] is the edit distance between a[:i] and b[:j].
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if equalRunes(a[i-1], b[j-1], o) {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+		}
+	}
+
+	similarity := 1.0
+	if n := max(len(a), len(b)); n > 0 {
+		similarity = 1 - float64(d[len(a)][len(b)])/float64(n)
+	}
+	return Check{
+		Expected:   string(b),
+		Similarity: similarity,
+		Grade:      SuggestGrade(similarity),
+		Diff:       diff(a, b, d, o),
+	}
+}
+
+// diff walks the edit distance table back from the end, collecting the
+// edits that turn a into b.
+func diff(a, b []rune, d [][]int, o MatchOptions) []Edit {
+	var edits []Edit
+	add := func(op EditOp, r rune) {
+		if n := len(edits); n > 0 && edits[n-1].Op == op {
+			edits[n-1].Text = string(r) + edits[n-1].Text
+			return
+		}
+		edits = append(edits, Edit{op, string(r)})
+	}
+
+	i, j := len(a), len(b)
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && equalRunes(a[i-1], b[j-1], o) && d[i][j] == d[i-1][j-1]:
+			add(Equal, a[i-1])
+			i, j = i-1, j-1
+		case j > 0 && d[i][j] == d[i][j-1]+1:
+			add(Insert, b[j-1])
+			j--
+		case i > 0 && d[i][j] == d[i-1][j]+1:
+			add(Delete, a[i-1])
+			i--
+		default: // substitution
+			add(Insert, b[j-1])
+			add(Delete, a[i-1])
+			i, j = i-1, j-1
+		}
+	}
+
+	for l, r := 0, len(edits)-1; l < r; l, r = l+1, r-1 {
+		edits[l], edits[r] = edits[r], edits[l]
+	}
+	return groupChanges(edits)
+}
+
+// groupChanges merges each run of changes between equal text into a single
+// delete followed by a single insert, so substitutions read as whole words
+// rather than alternating characters.
+func groupChanges(edits []Edit) []Edit {
+	var grouped []Edit
+	var del, ins strings.Builder
+	flush := func() {
+		if del.Len() > 0 {
+			grouped = append(grouped, Edit{Delete, del.String()})
+		}
+		if ins.Len() > 0 {
+			grouped = append(grouped, Edit{Insert, ins.String()})
+		}
+		del.Reset()
+		ins.Reset()
+	}
+	for _, e := range edits {
+		switch e.Op {
+		case Delete:
+			del.WriteString(e.Text)
+		case Insert:
+			ins.WriteString(e.Text)
+		default:
+			flush()
+			grouped = append(grouped, e)
+		}
+	}
+	flush()
+	return grouped
+}
+
+// prepare composes the answer's characters so each is a single rune and, if
+// whitespace is ignored, collapses every run of it into a single space.
+func prepare(s string, o MatchOptions) string {
+	s = norm.NFC.String(s)
+	if o.IgnoreSpace {
+		s = strings.Join(strings.Fields(s), " ")
+	}
+	return s
+}
+
+func equalRunes(a, b rune, o MatchOptions) bool {
+	if o.IgnoreDiacritics {
+		a, b = baseRune(a), baseRune(b)
+	}
+	if o.IgnoreCase {
+		return unicode.ToLower(a) == unicode.ToLower(b)
+	}
+	return a == b
+}
+
+// baseRune strips any diacritics from a rune.
+func baseRune(r rune) rune {
+	for _, d := range norm.NFD.String(string(r)) {
+		if !unicode.Is(unicode.Mn, d) {
+			return d
+		}
+	}
+	return r
+}
+
+func min(ns ...int) int {
+	m := ns[0]
+	for _, n := range ns[1:] {
+		if n < m {
+			m = n
+		}
+	}
+	return m
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
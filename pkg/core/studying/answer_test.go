@@ -0,0 +1,142 @@
+package studying
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+)
+
+func TestCheck(t *testing.T) {
+	one := 1.0 // computed at run time to match the similarity's rounding
+	ignoreAll := MatchOptions{IgnoreCase: true, IgnoreSpace: true, IgnoreDiacritics: true}
+	card := getting.Card{
+		Title:      "Spanish.adiós",
+		Desc:       "goodbye",
+		Answers:    []string{"bye"},
+		Reversible: true,
+	}
+	cz := getting.Card{
+		Title: "Go.Scheduler",
+		Desc:  "The {{c1::scheduler}} runs {{c1::goroutines}}",
+		Cloze: true,
+	}
+	tests := []struct {
+		name      string
+		item      Item
+		answer    string
+		options   MatchOptions
+		wantCheck Check
+	}{
+		{
+			name:    "Exact",
+			item:    Item{card, getting.Forward},
+			answer:  "goodbye",
+			options: MatchOptions{},
+			wantCheck: Check{
+				Expected: "goodbye", Similarity: 1, Grade: reviewing.Good,
+				Diff: []Edit{{Equal, "goodbye"}},
+			},
+		},
+		{
+			name:    "Cloze",
+			item:    Item{cz, "c1"},
+			answer:  "scheduler, goroutines",
+			options: MatchOptions{},
+			wantCheck: Check{
+				Expected: "scheduler, goroutines", Similarity: 1, Grade: reviewing.Good,
+				Diff: []Edit{{Equal, "scheduler, goroutines"}},
+			},
+		},
+		{
+			name:    "Alternative Answer",
+			item:    Item{card, getting.Forward},
+			answer:  "bye",
+			options: MatchOptions{},
+			wantCheck: Check{
+				Expected: "bye", Similarity: 1, Grade: reviewing.Good,
+				Diff: []Edit{{Equal, "bye"}},
+			},
+		},
+		{
+			name:    "Typo",
+			item:    Item{card, getting.Forward},
+			answer:  "godbye",
+			options: MatchOptions{},
+			wantCheck: Check{
+				Expected: "goodbye", Similarity: 1 - one/7, Grade: reviewing.Hard,
+				Diff: []Edit{{Equal, "g"}, {Insert, "o"}, {Equal, "odbye"}},
+			},
+		},
+		{
+			name:    "Wrong",
+			item:    Item{card, getting.Forward},
+			answer:  "hello",
+			options: MatchOptions{},
+			wantCheck: Check{
+				Expected: "goodbye", Similarity: 0, Grade: reviewing.Again,
+				Diff: []Edit{{Delete, "hello"}, {Insert, "goodbye"}},
+			},
+		},
+		{
+			name:    "Case And Space Not Ignored",
+			item:    Item{card, getting.Forward},
+			answer:  " Bye",
+			options: MatchOptions{},
+			wantCheck: Check{
+				Expected: "bye", Similarity: 0.5, Grade: reviewing.Again,
+				Diff: []Edit{{Delete, " B"}, {Insert, "b"}, {Equal, "ye"}},
+			},
+		},
+		{
+			name:    "Case And Space Ignored",
+			item:    Item{card, getting.Forward},
+			answer:  "  Good  BYE ",
+			options: ignoreAll,
+			wantCheck: Check{
+				Expected: "goodbye", Similarity: 1 - one/8, Grade: reviewing.Hard,
+				Diff: []Edit{{Equal, "Good"}, {Delete, " "}, {Equal, "BYE"}},
+			},
+		},
+		{
+			name:    "Diacritics Ignored",
+			item:    Item{card, getting.Reverse},
+			answer:  "Adios",
+			options: ignoreAll,
+			wantCheck: Check{
+				Expected: "adiós", Similarity: 1, Grade: reviewing.Good,
+				Diff: []Edit{{Equal, "Adios"}},
+			},
+		},
+		{
+			name:    "Diacritics Not Ignored",
+			item:    Item{card, getting.Reverse},
+			answer:  "adios",
+			options: MatchOptions{IgnoreCase: true},
+			wantCheck: Check{
+				Expected: "adiós", Similarity: 0.8, Grade: reviewing.Hard,
+				Diff: []Edit{{Equal, "adi"}, {Delete, "o"}, {Insert, "ó"}, {Equal, "s"}},
+			},
+		},
+		{
+			name:    "Empty",
+			item:    Item{getting.Card{Title: "Empty"}, getting.Forward},
+			answer:  "",
+			options: MatchOptions{},
+			wantCheck: Check{
+				Expected: "", Similarity: 1, Grade: reviewing.Good,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := tt.item.Check(tt.answer, tt.options)
+
+			if !reflect.DeepEqual(tt.wantCheck, check) {
+				t.Errorf("Incorrect check. Want %v, got %v", tt.wantCheck, check)
+			}
+		})
+	}
+}
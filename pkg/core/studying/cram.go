@@ -0,0 +1,41 @@
+package studying
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrUnknownOrder error = errors.New("unknown cram order")
+
+// Order is the order cards are crammed in.
+type Order int
+
+const (
+	Random Order = iota
+	Weakest
+)
+
+var orderNames = []string{"random", "weakest"}
+
+func ParseOrder(s string) (Order, error) {
+	for i, n := range orderNames {
+		if strings.EqualFold(s, n) {
+			return Order(i), nil
+		}
+	}
+	return 0, ErrUnknownOrder
+}
+
+func (o Order) String() string {
+	if o < Random || o > Weakest {
+		return "unknown"
+	}
+	return orderNames[o]
+}
+
+type CramOptions struct {
+	Order Order
+	// KeepSchedule drills the cards without recording the grades, leaving
+	// their long-term schedules untouched.
+	KeepSchedule bool
+}
@@ -0,0 +1,72 @@
+package studying
+
+import (
+	"github.com/jmcveigh55/flash/pkg/core/cloze"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/note"
+)
+
+// Item is a single face of a card to be studied.
+type Item struct {
+	Card getting.Card
+	Face string
+}
+
+// Prompt returns the side of the card shown before it is revealed. Cloze
+// cards show their description with the face's deletions masked, and note
+// cards the front of the face's template.
+func (i Item) Prompt() string {
+	if t, ok := i.template(); ok {
+		return note.Render(t.Front, i.Card.Note.Fields)
+	}
+	if i.Card.Cloze {
+		return cloze.Mask(i.Card.Desc, i.Face)
+	}
+	if i.Face == getting.Reverse {
+		return i.Card.Desc
+	}
+	return i.Card.Title
+}
+
+// Answer returns the side of the card hidden until it is revealed.
+func (i Item) Answer() string {
+	if t, ok := i.template(); ok {
+		return note.RenderBack(t.Front, t.Back, i.Card.Note.Fields)
+	}
+	if i.Card.Cloze {
+		return cloze.Reveal(i.Card.Desc, i.Face)
+	}
+	if i.Face == getting.Reverse {
+		return i.Card.Title
+	}
+	return i.Card.Desc
+}
+
+// template returns the template of a note card's face.
+func (i Item) template() (getting.Template, bool) {
+	if i.Card.Note == nil {
+		return getting.Template{}, false
+	}
+	return i.Card.Note.Template(i.Face)
+}
+
+func (i Item) schedule() getting.Schedule {
+	return i.Card.Schedule(i.Face)
+}
+
+// isNew reports whether the item has never been reviewed.
+func (i Item) isNew() bool {
+	_, ok := i.Card.Schedules[i.Face]
+	return !ok
+}
+
+// items returns an item for each face of the cards.
+func items(cards []getting.Card) []Item {
+	items := []Item{}
+	for _, c := range cards {
+		for _, f := range c.Faces() {
+			items = append(items, Item{c, f})
+		}
+	}
+	return items
+}
@@ -0,0 +1,180 @@
+package studying
+
+import (
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/storage"
+)
+
+// DailyCount counts the new and previously seen cards studied on a day
+// against a group's limits.
+type DailyCount struct {
+	Day     time.Time
+	New     int
+	Reviews int
+}
+
+// limit is the group whose daily limit applies to a card, and the limit.
+type limit struct {
+	group string
+	max   int
+}
+
+// limiter enforces the daily limits of the groups over a study session,
+// counting each card studied against the group whose limit applies to it. A
+// zero limit is inherited from the parent group. A group with a Target date
+// spreads its new cards evenly over the days left, within its own new card
+// limit.
+type limiter struct {
+	r      Repository
+	g      getting.Service
+	today  time.Time
+	limits map[string]configuring.Config
+	counts map[string]DailyCount
+}
+
+func newLimiter(r Repository, g getting.Service, now time.Time) *limiter {
+	y, m, d := now.Date()
+	return &limiter{
+		r:      r,
+		g:      g,
+		today:  time.Date(y, m, d, 0, 0, 0, 0, now.Location()),
+		limits: map[string]configuring.Config{},
+		counts: map[string]DailyCount{},
+	}
+}
+
+// filter keeps the items that fit in what is left of today's limits, in
+// order.
+func (l *limiter) filter(items []Item) ([]Item, error) {
+	type kind struct {
+		group string
+		new   bool
+	}
+	taken := map[kind]int{}
+	kept := []Item{}
+	for _, i := range items {
+		lim, err := l.limit(i)
+		if err != nil {
+			return nil, err
+		}
+		if lim.max != configuring.Unlimited {
+			count, err := l.count(lim.group)
+			if err != nil {
+				return nil, err
+			}
+			studied := count.Reviews
+			if i.isNew() {
+				studied = count.New
+			}
+			k := kind{lim.group, i.isNew()}
+			if studied+taken[k] >= lim.max {
+				continue
+			}
+			taken[k]++
+		}
+		kept = append(kept, i)
+	}
+	return kept, nil
+}
+
+// add counts the item as studied today.
+func (l *limiter) add(i Item) error {
+	lim, err := l.limit(i)
+	if err != nil || lim.max == configuring.Unlimited {
+		return err
+	}
+
+	count, err := l.count(lim.group)
+	if err != nil {
+		return err
+	}
+	if i.isNew() {
+		count.New++
+	} else {
+		count.Reviews++
+	}
+	l.counts[lim.group] = count
+	return l.r.SetDailyCount(lim.group, count)
+}
+
+// limit finds the closest group, from the item's own group up, that limits
+// items like it.
+func (l *limiter) limit(i Item) (limit, error) {
+	g, _ := storage.SplitPath(i.Card.Title)
+	for _, g := range configuring.Lineage(g) {
+		lims, err := l.groupLimits(g)
+		if err != nil {
+			return limit{}, err
+		}
+		n := lims.ReviewsPerDay
+		if i.isNew() {
+			n = lims.NewPerDay
+		}
+		if n != 0 {
+			return limit{g, n}, nil
+		}
+	}
+	return limit{max: configuring.Unlimited}, nil
+}
+
+func (l *limiter) groupLimits(g string) (configuring.Config, error) {
+	if lims, ok := l.limits[g]; ok {
+		return lims, nil
+	}
+	lims, err := l.r.GetConfig(g)
+	if err != nil {
+		return configuring.Config{}, err
+	}
+	if days := reviewing.DaysUntil(l.today, lims.Target); !lims.Target.IsZero() && days > 0 {
+		spread, err := l.spread(g, days)
+		if err != nil {
+			return configuring.Config{}, err
+		}
+		if spread > 0 && (lims.NewPerDay <= 0 || spread < lims.NewPerDay) {
+			lims.NewPerDay = spread
+		}
+	}
+	l.limits[g] = lims
+	return lims, nil
+}
+
+// spread returns how many new cards of the group to study each day to have
+// studied them all in the number of days, counting those studied today.
+func (l *limiter) spread(g string, days int) (int, error) {
+	cards, err := l.g.GetAllCards(g, getting.Unsuspended)
+	if err != nil {
+		return 0, err
+	}
+	count, err := l.count(g)
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := count.New
+	for _, i := range items(cards) {
+		if i.isNew() {
+			remaining++
+		}
+	}
+	return (remaining + days - 1) / days, nil
+}
+
+// count returns the group's count for today, starting afresh on a new day.
+func (l *limiter) count(g string) (DailyCount, error) {
+	if count, ok := l.counts[g]; ok {
+		return count, nil
+	}
+	count, err := l.r.GetDailyCount(g)
+	if err != nil {
+		return DailyCount{}, err
+	}
+	if !count.Day.Equal(l.today) {
+		count = DailyCount{Day: l.today}
+	}
+	l.counts[g] = count
+	return count, nil
+}
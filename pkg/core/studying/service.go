@@ -0,0 +1,97 @@
+package studying
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/storage"
+)
+
+type Service interface {
+	Study(string, getting.Query) (*Session, error)
+	Cram(string, getting.Query, CramOptions) (*Session, error)
+}
+
+type Repository interface {
+	GetConfig(string) (configuring.Config, error)
+	GetDailyCount(string) (DailyCount, error)
+	SetDailyCount(string, DailyCount) error
+}
+
+type service struct {
+	g     getting.Service
+	r     reviewing.Service
+	repo  Repository
+	clock storage.Clock
+}
+
+func New(g getting.Service, r reviewing.Service, repo Repository, c storage.Clock) *service {
+	return &service{g, r, repo, c}
+}
+
+// Study starts a session over the faces of the cards under the group that
+// match the tag query and are due, oldest first, within what is left of the
+// groups' daily limits. Suspended and buried cards are left out.
+func (s *service) Study(g string, q getting.Query) (*Session, error) {
+	cards, err := s.g.GetAllCards(g, getting.Unsuspended)
+	if err != nil {
+		return nil, err
+	}
+	cards = q.Select(cards)
+
+	now := s.clock.Now()
+	due := []Item{}
+	for _, i := range items(cards) {
+		if !i.schedule().Due.After(now) && !i.Card.Buried(now) {
+			due = append(due, i)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].schedule().Due.Before(due[j].schedule().Due)
+	})
+
+	limits := newLimiter(s.repo, s.g, now)
+	due, err = limits.filter(due)
+	if err != nil {
+		return nil, err
+	}
+
+	session := newSession(due, s.r, s.clock)
+	session.limits = limits
+	return session, nil
+}
+
+// Cram starts a session over every face of the unsuspended cards under the
+// group that match the tag query, regardless of when they are due. Missed
+// cards are repeated until they are recalled.
+func (s *service) Cram(g string, q getting.Query, o CramOptions) (*Session, error) {
+	cards, err := s.g.GetAllCards(g, getting.Unsuspended)
+	if err != nil {
+		return nil, err
+	}
+	cards = q.Select(cards)
+
+	queue := items(cards)
+	switch o.Order {
+	case Random:
+		rnd := rand.New(rand.NewSource(s.clock.Now().UnixNano()))
+		rnd.Shuffle(len(queue), func(i, j int) {
+			queue[i], queue[j] = queue[j], queue[i]
+		})
+	case Weakest:
+		sort.SliceStable(queue, func(i, j int) bool {
+			return queue[i].schedule().Interval < queue[j].schedule().Interval
+		})
+	default:
+		return nil, ErrUnknownOrder
+	}
+
+	session := newSession(queue, s.r, s.clock)
+	session.requeue = true
+	session.cram = true
+	session.record = !o.KeepSchedule
+	return session, nil
+}
@@ -0,0 +1,635 @@
+package studying
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+)
+
+var errGroupNotFound error = errors.New("group not found")
+
+var now = time.Date(2022, time.November, 1, 12, 0, 0, 0, time.UTC)
+
+type clockStub struct {
+	t time.Time
+}
+
+func (c *clockStub) Now() time.Time {
+	return c.t
+}
+
+type gettingStub struct {
+	cards []getting.Card
+}
+
+func newGettingStubWithCards() *gettingStub {
+	return &gettingStub{
+		cards: []getting.Card{
+			{
+				Title: "Group.Subject1", Desc: "Value1", Tags: []string{"go"},
+				Schedules: map[string]getting.Schedule{
+					getting.Forward: {Due: now.AddDate(0, 0, -1), Interval: 6},
+				},
+			},
+			{
+				Title: "Group.Subject2", Desc: "Value2",
+				Schedules: map[string]getting.Schedule{
+					getting.Forward: {Due: now.AddDate(0, 0, 1), Interval: 15},
+				},
+			},
+			{
+				Title: "Group.Reversible", Desc: "Value3", Reversible: true,
+				Schedules: map[string]getting.Schedule{
+					getting.Forward: {Due: now.AddDate(0, 0, 2), Interval: 10},
+					getting.Reverse: {Due: now.AddDate(0, 0, -2), Interval: 2},
+				},
+			},
+			{
+				Title: "Group.SubGroup.Subject1", Desc: "Value1", Tags: []string{"go", "old"},
+				Schedules: map[string]getting.Schedule{
+					getting.Forward: {Due: now.AddDate(0, 0, -3), Interval: 1},
+				},
+			},
+			{Title: "Group.SubGroup.Subject2", Desc: "Value2", Created: now},
+		},
+	}
+}
+
+// faces names the items as "title/face" for easy comparison.
+func faces(items []Item) []string {
+	var names []string
+	for _, i := range items {
+		names = append(names, i.Card.Title+"/"+i.Face)
+	}
+	return names
+}
+
+func (g *gettingStub) GetCards(string, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (g *gettingStub) GetAllCards(group string, f getting.Filter) ([]getting.Card, error) {
+	if group != "Group" {
+		return []getting.Card{}, errGroupNotFound
+	}
+	cards := []getting.Card{}
+	for _, c := range g.cards {
+		if f == getting.All || c.Suspended == (f == getting.Suspended) {
+			cards = append(cards, c)
+		}
+	}
+	return cards, nil
+}
+
+func (g *gettingStub) GetCardsInBox(string, int, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (g *gettingStub) GetLeeches(string) ([]getting.Card, error) {
+	return nil, nil
+}
+
+type review struct {
+	group    string
+	title    string
+	face     string
+	grade    reviewing.Grade
+	duration time.Duration
+	cram     bool
+}
+
+type reviewingStub struct {
+	reviews []review
+}
+
+func (r *reviewingStub) ReviewCard(g string, c reviewing.Card, gr reviewing.Grade) (reviewing.Card, error) {
+	r.reviews = append(r.reviews, review{g, c.Title, c.Face, gr, c.Duration, c.Cram})
+	return c, nil
+}
+
+type repositoryStub struct {
+	configs map[string]configuring.Config
+	counts  map[string]DailyCount
+}
+
+func (r *repositoryStub) GetConfig(g string) (configuring.Config, error) {
+	return r.configs[g], nil
+}
+
+func (r *repositoryStub) GetDailyCount(g string) (DailyCount, error) {
+	return r.counts[g], nil
+}
+
+func (r *repositoryStub) SetDailyCount(g string, c DailyCount) error {
+	if r.counts == nil {
+		r.counts = map[string]DailyCount{}
+	}
+	r.counts[g] = c
+	return nil
+}
+
+func TestStudy(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		query   string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			want: []string{
+				"Group.SubGroup.Subject1/forward",
+				"Group.Reversible/reverse",
+				"Group.Subject1/forward",
+				"Group.SubGroup.Subject2/forward",
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Tagged",
+			group: "Group",
+			query: "go",
+			want: []string{
+				"Group.SubGroup.Subject1/forward",
+				"Group.Subject1/forward",
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Tagged Not",
+			group: "Group",
+			query: "go+!old",
+			want: []string{
+				"Group.Subject1/forward",
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Group Not Found",
+			group:   "NotFound",
+			want:    nil,
+			wantErr: errGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := getting.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			ss := New(newGettingStubWithCards(), &reviewingStub{}, &repositoryStub{}, &clockStub{now})
+			session, err := ss.Study(tt.group, q)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			var got []Item
+			for session != nil {
+				i, ok := session.Next()
+				if !ok {
+					break
+				}
+				got = append(got, i)
+				session.Grade(reviewing.Good)
+			}
+
+			if !reflect.DeepEqual(tt.want, faces(got)) {
+				t.Errorf("Incorrect items. Want %v, got %v", tt.want, faces(got))
+			}
+		})
+	}
+}
+
+func TestStudySuspendedAndBuried(t *testing.T) {
+	g := &gettingStub{
+		cards: []getting.Card{
+			{Title: "Group.Subject1", Desc: "Value1", Created: now},
+			{Title: "Group.Subject2", Desc: "Value2", Created: now, Suspended: true},
+			{Title: "Group.Subject3", Desc: "Value3", Created: now, BuriedTill: now.Add(time.Hour)},
+			{Title: "Group.Subject4", Desc: "Value4", Created: now, BuriedTill: now},
+		},
+	}
+	ss := New(g, &reviewingStub{}, &repositoryStub{}, &clockStub{now})
+
+	session, err := ss.Study("Group", getting.Query{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []string{"Group.Subject1/forward", "Group.Subject4/forward"}
+	if got := faces(session.queue); !reflect.DeepEqual(want, got) {
+		t.Errorf("Incorrect study items. Want %v, got %v", want, got)
+	}
+
+	session, err = ss.Cram("Group", getting.Query{}, CramOptions{Order: Weakest})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want = []string{"Group.Subject1/forward", "Group.Subject3/forward", "Group.Subject4/forward"}
+	if got := faces(session.queue); !reflect.DeepEqual(want, got) {
+		t.Errorf("Incorrect cram items. Want %v, got %v", want, got)
+	}
+}
+
+func TestStudyLimits(t *testing.T) {
+	today := time.Date(2022, time.November, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		repo       *repositoryStub
+		want       []string
+		wantCounts map[string]DailyCount
+	}{
+		{
+			name: "Review Limit",
+			repo: &repositoryStub{
+				configs: map[string]configuring.Config{"Group": {ReviewsPerDay: 2}},
+			},
+			want: []string{
+				"Group.SubGroup.Subject1/forward",
+				"Group.Reversible/reverse",
+				"Group.SubGroup.Subject2/forward",
+			},
+			wantCounts: map[string]DailyCount{"Group": {Day: today, Reviews: 2}},
+		},
+		{
+			name: "New Limit Reached",
+			repo: &repositoryStub{
+				configs: map[string]configuring.Config{"Group": {NewPerDay: 1}},
+				counts:  map[string]DailyCount{"Group": {Day: today, New: 1}},
+			},
+			want: []string{
+				"Group.SubGroup.Subject1/forward",
+				"Group.Reversible/reverse",
+				"Group.Subject1/forward",
+			},
+			wantCounts: map[string]DailyCount{"Group": {Day: today, New: 1}},
+		},
+		{
+			name: "Target Date Spread",
+			repo: &repositoryStub{
+				configs: map[string]configuring.Config{"Group": {Target: today.AddDate(0, 0, 10)}},
+				counts:  map[string]DailyCount{"Group": {Day: today, New: 1}},
+			},
+			want: []string{
+				"Group.SubGroup.Subject1/forward",
+				"Group.Reversible/reverse",
+				"Group.Subject1/forward",
+			},
+			wantCounts: map[string]DailyCount{"Group": {Day: today, New: 1}},
+		},
+		{
+			name: "Target Date Passed",
+			repo: &repositoryStub{
+				configs: map[string]configuring.Config{"Group": {Target: today.AddDate(0, 0, -1)}},
+			},
+			want: []string{
+				"Group.SubGroup.Subject1/forward",
+				"Group.Reversible/reverse",
+				"Group.Subject1/forward",
+				"Group.SubGroup.Subject2/forward",
+			},
+		},
+		{
+			name: "Day Rollover",
+			repo: &repositoryStub{
+				configs: map[string]configuring.Config{"Group": {NewPerDay: 1}},
+				counts:  map[string]DailyCount{"Group": {Day: today.AddDate(0, 0, -1), New: 1}},
+			},
+			want: []string{
+				"Group.SubGroup.Subject1/forward",
+				"Group.Reversible/reverse",
+				"Group.Subject1/forward",
+				"Group.SubGroup.Subject2/forward",
+			},
+			wantCounts: map[string]DailyCount{"Group": {Day: today, New: 1}},
+		},
+		{
+			name: "Inherited By Subgroup",
+			repo: &repositoryStub{
+				configs: map[string]configuring.Config{"Group": {ReviewsPerDay: 1}},
+			},
+			want: []string{
+				"Group.SubGroup.Subject1/forward",
+				"Group.SubGroup.Subject2/forward",
+			},
+			wantCounts: map[string]DailyCount{"Group": {Day: today, Reviews: 1}},
+		},
+		{
+			name: "Overridden By Subgroup",
+			repo: &repositoryStub{
+				configs: map[string]configuring.Config{
+					"Group":          {ReviewsPerDay: 1},
+					"Group.SubGroup": {ReviewsPerDay: configuring.Unlimited},
+				},
+			},
+			want: []string{
+				"Group.SubGroup.Subject1/forward",
+				"Group.Reversible/reverse",
+				"Group.SubGroup.Subject2/forward",
+			},
+			wantCounts: map[string]DailyCount{"Group": {Day: today, Reviews: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := New(newGettingStubWithCards(), &reviewingStub{}, tt.repo, &clockStub{now})
+			session, err := ss.Study("Group", getting.Query{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			var got []Item
+			for {
+				i, ok := session.Next()
+				if !ok {
+					break
+				}
+				got = append(got, i)
+				session.Grade(reviewing.Good)
+			}
+
+			if !reflect.DeepEqual(tt.want, faces(got)) {
+				t.Errorf("Incorrect items. Want %v, got %v", tt.want, faces(got))
+			}
+
+			if !reflect.DeepEqual(tt.wantCounts, tt.repo.counts) {
+				t.Errorf("Incorrect counts. Want %v, got %v", tt.wantCounts, tt.repo.counts)
+			}
+		})
+	}
+}
+
+func TestSessionGrade(t *testing.T) {
+	clock := &clockStub{now}
+	r := &reviewingStub{}
+	ss := New(newGettingStubWithCards(), r, &repositoryStub{}, clock)
+	session, err := ss.Study("Group", getting.Query{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	grades := []reviewing.Grade{reviewing.Again, reviewing.Good, reviewing.Good, reviewing.Easy}
+	for i, g := range grades {
+		clock.t = clock.t.Add(time.Duration(i+1) * 5 * time.Second)
+		if _, err := session.Grade(g); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+
+	if _, err := session.Grade(reviewing.Good); err != ErrSessionFinished {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrSessionFinished, err)
+	}
+
+	wantReviews := []review{
+		{"Group.SubGroup", "Subject1", getting.Forward, reviewing.Again, 5 * time.Second, false},
+		{"Group", "Reversible", getting.Reverse, reviewing.Good, 10 * time.Second, false},
+		{"Group", "Subject1", getting.Forward, reviewing.Good, 15 * time.Second, false},
+		{"Group.SubGroup", "Subject2", getting.Forward, reviewing.Easy, 20 * time.Second, false},
+	}
+	if !reflect.DeepEqual(wantReviews, r.reviews) {
+		t.Errorf("Incorrect reviews. Want %v, got %v", wantReviews, r.reviews)
+	}
+
+	wantSummary := Summary{Reviewed: 4, Grades: [4]int{1, 0, 2, 1}, Duration: 50 * time.Second}
+	if got := session.Summary(); !reflect.DeepEqual(wantSummary, got) {
+		t.Errorf("Incorrect summary. Want %v, got %v", wantSummary, got)
+	}
+
+	if session.Remaining() != 0 {
+		t.Errorf("Incorrect remaining. Want 0, got %d", session.Remaining())
+	}
+}
+
+func TestCram(t *testing.T) {
+	tests := []struct {
+		name        string
+		group       string
+		options     CramOptions
+		grades      []reviewing.Grade
+		want        []string
+		wantReviews int
+		wantErr     error
+	}{
+		{
+			name:    "Weakest",
+			group:   "Group",
+			options: CramOptions{Order: Weakest},
+			grades: []reviewing.Grade{
+				reviewing.Good, reviewing.Good, reviewing.Good,
+				reviewing.Good, reviewing.Good, reviewing.Good,
+			},
+			want: []string{
+				"Group.SubGroup.Subject2/forward",
+				"Group.SubGroup.Subject1/forward",
+				"Group.Reversible/reverse",
+				"Group.Subject1/forward",
+				"Group.Reversible/forward",
+				"Group.Subject2/forward",
+			},
+			wantReviews: 6,
+			wantErr:     nil,
+		},
+		{
+			name:    "Requeue Missed",
+			group:   "Group",
+			options: CramOptions{Order: Weakest},
+			grades: []reviewing.Grade{
+				reviewing.Again, reviewing.Good, reviewing.Good, reviewing.Good,
+				reviewing.Good, reviewing.Good, reviewing.Again, reviewing.Good,
+			},
+			want: []string{
+				"Group.SubGroup.Subject2/forward",
+				"Group.SubGroup.Subject1/forward",
+				"Group.Reversible/reverse",
+				"Group.Subject1/forward",
+				"Group.Reversible/forward",
+				"Group.Subject2/forward",
+				"Group.SubGroup.Subject2/forward",
+				"Group.SubGroup.Subject2/forward",
+			},
+			wantReviews: 8,
+			wantErr:     nil,
+		},
+		{
+			name:    "Keep Schedule",
+			group:   "Group",
+			options: CramOptions{Order: Weakest, KeepSchedule: true},
+			grades: []reviewing.Grade{
+				reviewing.Good, reviewing.Good, reviewing.Good,
+				reviewing.Good, reviewing.Good, reviewing.Good,
+			},
+			want: []string{
+				"Group.SubGroup.Subject2/forward",
+				"Group.SubGroup.Subject1/forward",
+				"Group.Reversible/reverse",
+				"Group.Subject1/forward",
+				"Group.Reversible/forward",
+				"Group.Subject2/forward",
+			},
+			wantReviews: 0,
+			wantErr:     nil,
+		},
+		{
+			name:    "Unknown Order",
+			group:   "Group",
+			options: CramOptions{Order: Order(5)},
+			want:    nil,
+			wantErr: ErrUnknownOrder,
+		},
+		{
+			name:    "Group Not Found",
+			group:   "NotFound",
+			options: CramOptions{Order: Random},
+			want:    nil,
+			wantErr: errGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &reviewingStub{}
+			ss := New(newGettingStubWithCards(), r, &repositoryStub{}, &clockStub{now})
+			session, err := ss.Cram(tt.group, getting.Query{}, tt.options)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			var got []Item
+			for _, g := range tt.grades {
+				i, ok := session.Next()
+				if !ok {
+					break
+				}
+				got = append(got, i)
+				session.Grade(g)
+			}
+
+			if !reflect.DeepEqual(tt.want, faces(got)) {
+				t.Errorf("Incorrect items. Want %v, got %v", tt.want, faces(got))
+			}
+
+			if session != nil && session.Remaining() != 0 {
+				t.Errorf("Incorrect remaining. Want 0, got %d", session.Remaining())
+			}
+
+			if len(r.reviews) != tt.wantReviews {
+				t.Errorf("Incorrect reviews. Want %d, got %d", tt.wantReviews, len(r.reviews))
+			}
+			for _, rv := range r.reviews {
+				if !rv.cram {
+					t.Errorf("Incorrect review. Want it crammed, got %v", rv)
+				}
+			}
+		})
+	}
+}
+
+func TestCramRandom(t *testing.T) {
+	ss := New(newGettingStubWithCards(), &reviewingStub{}, &repositoryStub{}, &clockStub{now})
+	session, err := ss.Cram("Group", getting.Query{}, CramOptions{Order: Random})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var got []Item
+	for {
+		i, ok := session.Next()
+		if !ok {
+			break
+		}
+		got = append(got, i)
+		session.Grade(reviewing.Easy)
+	}
+	names := faces(got)
+	sort.Strings(names)
+
+	want := []string{
+		"Group.Reversible/forward",
+		"Group.Reversible/reverse",
+		"Group.SubGroup.Subject1/forward",
+		"Group.SubGroup.Subject2/forward",
+		"Group.Subject1/forward",
+		"Group.Subject2/forward",
+	}
+	if !reflect.DeepEqual(want, names) {
+		t.Errorf("Incorrect items. Want %v, got %v", want, names)
+	}
+}
+
+func TestItem(t *testing.T) {
+	c := getting.Card{Title: "Group.Term", Desc: "Definition", Reversible: true}
+	cz := getting.Card{Title: "Group.Cloze", Desc: "The {{c1::scheduler}} runs in {{c2::a goroutine::where}}", Cloze: true}
+	n := getting.Card{Title: "Group.Cat", Note: &getting.Note{
+		Type:   "Vocab",
+		Fields: map[string]string{"Word": "猫", "Meaning": "cat"},
+		Templates: []getting.Template{
+			{Name: "Recognise", Front: "{{Word}}", Back: "{{Meaning}}"},
+			{Name: "Recall", Front: "{{Meaning}}", Back: "{{FrontSide}}: {{Word}}"},
+		},
+	}}
+	tests := []struct {
+		name       string
+		item       Item
+		wantPrompt string
+		wantAnswer string
+	}{
+		{
+			name:       "Forward",
+			item:       Item{c, getting.Forward},
+			wantPrompt: "Group.Term",
+			wantAnswer: "Definition",
+		},
+		{
+			name:       "Reverse",
+			item:       Item{c, getting.Reverse},
+			wantPrompt: "Definition",
+			wantAnswer: "Group.Term",
+		},
+		{
+			name:       "Cloze",
+			item:       Item{cz, "c1"},
+			wantPrompt: "The [...] runs in a goroutine",
+			wantAnswer: "The scheduler runs in a goroutine",
+		},
+		{
+			name:       "Cloze Hint",
+			item:       Item{cz, "c2"},
+			wantPrompt: "The scheduler runs in [where]",
+			wantAnswer: "The scheduler runs in a goroutine",
+		},
+		{
+			name:       "Note",
+			item:       Item{n, "Recognise"},
+			wantPrompt: "猫",
+			wantAnswer: "cat",
+		},
+		{
+			name:       "Note Front Side",
+			item:       Item{n, "Recall"},
+			wantPrompt: "cat",
+			wantAnswer: "cat: 猫",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.item.Prompt(); got != tt.wantPrompt {
+				t.Errorf("Incorrect prompt. Want %v, got %v", tt.wantPrompt, got)
+			}
+			if got := tt.item.Answer(); got != tt.wantAnswer {
+				t.Errorf("Incorrect answer. Want %v, got %v", tt.wantAnswer, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package studying
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/storage"
+)
+
+var ErrSessionFinished error = errors.New("study session has no cards left")
+
+type Summary struct {
+	Reviewed int
+	Grades   [4]int
+	Duration time.Duration
+}
+
+// Session walks through a queue of card faces, recording the grade given to each
+// one with the reviewing service.
+type Session struct {
+	queue   []Item
+	r       reviewing.Service
+	clock   storage.Clock
+	started time.Time
+	shown   time.Time
+	summary Summary
+
+	// requeue sends missed cards to the back of the queue until recalled.
+	requeue bool
+	// record writes each grade back to the card's schedule.
+	record bool
+	// cram marks the reviews as crammed, so missing a card is not a lapse.
+	cram bool
+	// limits counts each graded item against the daily limits, if set.
+	limits *limiter
+}
+
+func newSession(q []Item, r reviewing.Service, c storage.Clock) *Session {
+	return &Session{
+		queue:   q,
+		r:       r,
+		clock:   c,
+		started: c.Now(),
+		shown:   c.Now(),
+		record:  true,
+	}
+}
+
+// Next returns the item currently being studied and whether there is one.
+func (s *Session) Next() (Item, bool) {
+	if len(s.queue) == 0 {
+		return Item{}, false
+	}
+	return s.queue[0], true
+}
+
+// Remaining returns the number of items left in the session.
+func (s *Session) Remaining() int {
+	return len(s.queue)
+}
+
+// Grade records the grade for the current item and moves on to the next.
+func (s *Session) Grade(g reviewing.Grade) (reviewing.Card, error) {
+	i, ok := s.Next()
+	if !ok {
+		return reviewing.Card{}, ErrSessionFinished
+	}
+
+	if !g.Valid() {
+		return reviewing.Card{}, reviewing.ErrInvalidGrade
+	}
+
+	now := s.clock.Now()
+	group, title := storage.SplitPath(i.Card.Title)
+	rc := reviewing.Card{Title: title, Face: i.Face, Duration: now.Sub(s.shown), Cram: s.cram}
+	if s.record {
+		var err error
+		rc, err = s.r.ReviewCard(group, rc, g)
+		if err != nil {
+			return rc, err
+		}
+	}
+	if s.limits != nil {
+		if err := s.limits.add(i); err != nil {
+			return rc, err
+		}
+	}
+
+	s.shown = now
+	s.queue = s.queue[1:]
+	if s.requeue && g == reviewing.Again {
+		s.queue = append(s.queue, i)
+	}
+	s.summary.Reviewed++
+	s.summary.Grades[g]++
+	return rc, nil
+}
+
+// Summary returns the results of the session so far.
+func (s *Session) Summary() Summary {
+	summary := s.summary
+	summary.Duration = s.clock.Now().Sub(s.started)
+	return summary
+}
@@ -0,0 +1,5 @@
+package suspending
+
+type Card struct {
+	Title string
+}
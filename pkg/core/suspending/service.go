@@ -0,0 +1,61 @@
+package suspending
+
+import "errors"
+
+var (
+	ErrCardEmptyTitle error = errors.New("card has an empty title")
+	ErrGroupEmptyName error = errors.New("group has an empty name")
+)
+
+// Service suspends cards, taking them out of study sessions until they are
+// unsuspended. A group is suspended by suspending every card under it, so
+// the group must be named rather than left to mean every card.
+type Service interface {
+	SuspendCard(string, Card) error
+	UnsuspendCard(string, Card) error
+	SuspendGroup(string) error
+	UnsuspendGroup(string) error
+}
+
+type Repository interface {
+	SetCardSuspended(string, Card, bool) error
+	SetGroupSuspended(string, bool) error
+}
+
+type service struct {
+	r Repository
+}
+
+func New(r Repository) *service {
+	return &service{r}
+}
+
+func (s *service) SuspendCard(g string, c Card) error {
+	return s.setCardSuspended(g, c, true)
+}
+
+func (s *service) UnsuspendCard(g string, c Card) error {
+	return s.setCardSuspended(g, c, false)
+}
+
+func (s *service) SuspendGroup(g string) error {
+	return s.setGroupSuspended(g, true)
+}
+
+func (s *service) UnsuspendGroup(g string) error {
+	return s.setGroupSuspended(g, false)
+}
+
+func (s *service) setGroupSuspended(g string, suspended bool) error {
+	if g == "" {
+		return ErrGroupEmptyName
+	}
+	return s.r.SetGroupSuspended(g, suspended)
+}
+
+func (s *service) setCardSuspended(g string, c Card, suspended bool) error {
+	if c.Title == "" {
+		return ErrCardEmptyTitle
+	}
+	return s.r.SetCardSuspended(g, c, suspended)
+}
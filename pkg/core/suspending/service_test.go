@@ -0,0 +1,242 @@
+package suspending
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var (
+	errCardNotFound  error = errors.New("card not found")
+	errGroupNotFound error = errors.New("group not found")
+)
+
+type repositoryStub struct {
+	suspended map[string]bool
+}
+
+func newRepositoryStubWithCards() *repositoryStub {
+	return &repositoryStub{
+		suspended: map[string]bool{
+			"Subject1":                false,
+			"Group.Subject1":          false,
+			"Group.Subject2":          true,
+			"Group.SubGroup.Subject1": false,
+		},
+	}
+}
+
+func (r *repositoryStub) SetCardSuspended(g string, c Card, suspended bool) error {
+	if g != "" {
+		c.Title = g + "." + c.Title
+	}
+	if _, ok := r.suspended[c.Title]; !ok {
+		return errCardNotFound
+	}
+	r.suspended[c.Title] = suspended
+	return nil
+}
+
+func (r *repositoryStub) SetGroupSuspended(g string, suspended bool) error {
+	found := false
+	for title := range r.suspended {
+		if g == "" || strings.HasPrefix(title, g+".") {
+			r.suspended[title] = suspended
+			found = true
+		}
+	}
+	if !found {
+		return errGroupNotFound
+	}
+	return nil
+}
+
+func TestSuspendCard(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		card    Card
+		suspend bool
+		want    map[string]bool
+		wantErr error
+	}{
+		{
+			name:    "Suspend",
+			group:   "Group",
+			card:    Card{Title: "Subject1"},
+			suspend: true,
+			want: map[string]bool{
+				"Subject1":                false,
+				"Group.Subject1":          true,
+				"Group.Subject2":          true,
+				"Group.SubGroup.Subject1": false,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Unsuspend",
+			group:   "Group",
+			card:    Card{Title: "Subject2"},
+			suspend: false,
+			want: map[string]bool{
+				"Subject1":                false,
+				"Group.Subject1":          false,
+				"Group.Subject2":          false,
+				"Group.SubGroup.Subject1": false,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Card Not Found",
+			group:   "Group",
+			card:    Card{Title: "Subject3"},
+			suspend: true,
+			want: map[string]bool{
+				"Subject1":                false,
+				"Group.Subject1":          false,
+				"Group.Subject2":          true,
+				"Group.SubGroup.Subject1": false,
+			},
+			wantErr: errCardNotFound,
+		},
+		{
+			name:    "Card Empty Title",
+			group:   "Group",
+			card:    Card{Title: ""},
+			suspend: true,
+			want: map[string]bool{
+				"Subject1":                false,
+				"Group.Subject1":          false,
+				"Group.Subject2":          true,
+				"Group.SubGroup.Subject1": false,
+			},
+			wantErr: ErrCardEmptyTitle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepositoryStubWithCards()
+			ss := New(repo)
+			var err error
+			if tt.suspend {
+				err = ss.SuspendCard(tt.group, tt.card)
+			} else {
+				err = ss.UnsuspendCard(tt.group, tt.card)
+			}
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, repo.suspended) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, repo.suspended)
+			}
+		})
+	}
+}
+
+func TestSuspendGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		suspend bool
+		want    map[string]bool
+		wantErr error
+	}{
+		{
+			name:    "Suspend",
+			group:   "Group",
+			suspend: true,
+			want: map[string]bool{
+				"Subject1":                false,
+				"Group.Subject1":          true,
+				"Group.Subject2":          true,
+				"Group.SubGroup.Subject1": true,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Unsuspend",
+			group:   "Group",
+			suspend: false,
+			want: map[string]bool{
+				"Subject1":                false,
+				"Group.Subject1":          false,
+				"Group.Subject2":          false,
+				"Group.SubGroup.Subject1": false,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Sub Group",
+			group:   "Group.SubGroup",
+			suspend: true,
+			want: map[string]bool{
+				"Subject1":                false,
+				"Group.Subject1":          false,
+				"Group.Subject2":          true,
+				"Group.SubGroup.Subject1": true,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Group Not Found",
+			group:   "NotFound",
+			suspend: true,
+			want: map[string]bool{
+				"Subject1":                false,
+				"Group.Subject1":          false,
+				"Group.Subject2":          true,
+				"Group.SubGroup.Subject1": false,
+			},
+			wantErr: errGroupNotFound,
+		},
+		{
+			name:    "Group Empty Name",
+			group:   "",
+			suspend: true,
+			want: map[string]bool{
+				"Subject1":                false,
+				"Group.Subject1":          false,
+				"Group.Subject2":          true,
+				"Group.SubGroup.Subject1": false,
+			},
+			wantErr: ErrGroupEmptyName,
+		},
+		{
+			name:    "Unsuspend Group Empty Name",
+			group:   "",
+			suspend: false,
+			want: map[string]bool{
+				"Subject1":                false,
+				"Group.Subject1":          false,
+				"Group.Subject2":          true,
+				"Group.SubGroup.Subject1": false,
+			},
+			wantErr: ErrGroupEmptyName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepositoryStubWithCards()
+			ss := New(repo)
+			var err error
+			if tt.suspend {
+				err = ss.SuspendGroup(tt.group)
+			} else {
+				err = ss.UnsuspendGroup(tt.group)
+			}
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, repo.suspended) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, repo.suspended)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package tagging
+
+type Card struct {
+	ID    string
+	Title string
+}
+
+// Tag is a tag and how many cards have it.
+type Tag struct {
+	Name  string
+	Count int
+}
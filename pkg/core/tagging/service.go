@@ -0,0 +1,93 @@
+package tagging
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+)
+
+var (
+	ErrCardEmptyTitle error = errors.New("card has an empty title")
+	ErrTagsNotFound   error = errors.New("no tags given")
+	ErrInvalidTag     error = errors.New("tag may only contain letters, digits and _-./:")
+)
+
+// Service tags cards with cross-cutting topics, beyond the group they are
+// in.
+type Service interface {
+	AddTags(string, Card, []string) error
+	RemoveTags(string, Card, []string) error
+	GetTags(string) ([]Tag, error)
+}
+
+type Repository interface {
+	AddCardTags(string, Card, []string) error
+	RemoveCardTags(string, Card, []string) error
+}
+
+type service struct {
+	g getting.Service
+	r Repository
+}
+
+func New(g getting.Service, r Repository) *service {
+	return &service{g, r}
+}
+
+func (s *service) AddTags(g string, c Card, tags []string) error {
+	if err := validate(c, tags); err != nil {
+		return err
+	}
+	for _, t := range tags {
+		if !getting.ValidTag(t) {
+			return ErrInvalidTag
+		}
+	}
+	return s.r.AddCardTags(g, c, tags)
+}
+
+func (s *service) RemoveTags(g string, c Card, tags []string) error {
+	if err := validate(c, tags); err != nil {
+		return err
+	}
+	return s.r.RemoveCardTags(g, c, tags)
+}
+
+// GetTags returns the tags of the cards under the group, suspended or not,
+// with the most used first.
+func (s *service) GetTags(g string) ([]Tag, error) {
+	cards, err := s.g.GetAllCards(g, getting.All)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{}
+	for _, c := range cards {
+		for _, t := range c.Tags {
+			counts[t]++
+		}
+	}
+
+	tags := []Tag{}
+	for name, count := range counts {
+		tags = append(tags, Tag{name, count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Name < tags[j].Name
+	})
+	return tags, nil
+}
+
+func validate(c Card, tags []string) error {
+	if c.ID == "" && c.Title == "" {
+		return ErrCardEmptyTitle
+	}
+	if len(tags) == 0 {
+		return ErrTagsNotFound
+	}
+	return nil
+}
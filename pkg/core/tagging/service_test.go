@@ -0,0 +1,212 @@
+package tagging
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+)
+
+var (
+	errCardNotFound  error = errors.New("card not found")
+	errGroupNotFound error = errors.New("group not found")
+)
+
+// storeStub is both the getting service and the repository.
+type storeStub struct {
+	tags map[string][]string
+}
+
+func newStoreStubWithCards() *storeStub {
+	return &storeStub{
+		tags: map[string][]string{
+			"Subject1":                {"go"},
+			"Group.Subject1":          {"go", "old"},
+			"Group.Subject2":          nil,
+			"Group.SubGroup.Subject1": {"concurrency", "go"},
+		},
+	}
+}
+
+func (s *storeStub) GetCards(string, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (s *storeStub) GetAllCards(g string, _ getting.Filter) ([]getting.Card, error) {
+	var cards []getting.Card
+	for title, tags := range s.tags {
+		if g == "" || strings.HasPrefix(title, g+".") {
+			cards = append(cards, getting.Card{Title: title, Tags: tags})
+		}
+	}
+	if len(cards) == 0 {
+		return cards, errGroupNotFound
+	}
+	return cards, nil
+}
+
+func (s *storeStub) GetCardsInBox(string, int, getting.Filter) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (s *storeStub) GetLeeches(string) ([]getting.Card, error) {
+	return nil, nil
+}
+
+func (s *storeStub) AddCardTags(g string, c Card, tags []string) error {
+	title := g + "." + c.Title
+	if _, ok := s.tags[title]; !ok {
+		return errCardNotFound
+	}
+	s.tags[title] = append(s.tags[title], tags...)
+	return nil
+}
+
+func (s *storeStub) RemoveCardTags(g string, c Card, tags []string) error {
+	title := g + "." + c.Title
+	if _, ok := s.tags[title]; !ok {
+		return errCardNotFound
+	}
+	kept := []string{}
+	for _, t := range s.tags[title] {
+		if !contains(tags, t) {
+			kept = append(kept, t)
+		}
+	}
+	s.tags[title] = kept
+	return nil
+}
+
+func contains(tags []string, t string) bool {
+	for _, tag := range tags {
+		if tag == t {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAddTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		card    Card
+		tags    []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "Normal",
+			card:    Card{Title: "Subject2"},
+			tags:    []string{"go", "new"},
+			want:    []string{"go", "new"},
+			wantErr: nil,
+		},
+		{
+			name:    "Invalid Tag",
+			card:    Card{Title: "Subject2"},
+			tags:    []string{"go", "a,b"},
+			want:    nil,
+			wantErr: ErrInvalidTag,
+		},
+		{
+			name:    "No Tags",
+			card:    Card{Title: "Subject2"},
+			tags:    nil,
+			want:    nil,
+			wantErr: ErrTagsNotFound,
+		},
+		{
+			name:    "Empty Title",
+			card:    Card{},
+			tags:    []string{"go"},
+			want:    nil,
+			wantErr: ErrCardEmptyTitle,
+		},
+		{
+			name:    "Card Not Found",
+			card:    Card{Title: "NotFound"},
+			tags:    []string{"go"},
+			want:    nil,
+			wantErr: errCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newStoreStubWithCards()
+			err := New(store, store).AddTags("Group", tt.card, tt.tags)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if got := store.tags["Group.Subject2"]; !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect tags. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRemoveTags(t *testing.T) {
+	store := newStoreStubWithCards()
+	if err := New(store, store).RemoveTags("Group", Card{Title: "Subject1"}, []string{"old"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []string{"go"}
+	if got := store.tags["Group.Subject1"]; !reflect.DeepEqual(want, got) {
+		t.Errorf("Incorrect tags. Want %v, got %v", want, got)
+	}
+}
+
+func TestGetTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		want    []Tag
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "",
+			want: []Tag{
+				{"go", 3},
+				{"concurrency", 1},
+				{"old", 1},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Sub Group",
+			group: "Group.SubGroup",
+			want: []Tag{
+				{"concurrency", 1},
+				{"go", 1},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Group Not Found",
+			group:   "NotFound",
+			want:    nil,
+			wantErr: errGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newStoreStubWithCards()
+			got, err := New(store, store).GetTags(tt.group)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("Incorrect tags. Want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -1,6 +1,14 @@
 package updating
 
 type Card struct {
+	ID    string
 	Title string
 	Desc  string
+	// Answers replaces the card's alternative answers unless it is nil.
+	Answers []string
+	// Tags replaces the card's tags unless it is nil.
+	Tags []string
+	// Clozes holds the cloze faces of the new description, filled in by
+	// the service. A cloze card keeps the schedules of these faces only.
+	Clozes []string
 }
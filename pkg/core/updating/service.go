@@ -1,8 +1,16 @@
 package updating
 
-import "errors"
+import (
+	"errors"
 
-var ErrCardEmptyTitle error = errors.New("card has an empty title")
+	"github.com/jmcveigh55/flash/pkg/core/cloze"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+)
+
+var (
+	ErrCardEmptyTitle error = errors.New("card has an empty title")
+	ErrInvalidTag     error = errors.New("tag may only contain letters, digits and _-./:")
+)
 
 type Service interface {
 	UpdateCard(string, Card) error
@@ -21,8 +29,14 @@ func New(r Repository) *service {
 }
 
 func (s *service) UpdateCard(g string, c Card) error {
-	if c.Title == "" {
+	if c.ID == "" && c.Title == "" {
 		return ErrCardEmptyTitle
 	}
+	for _, t := range c.Tags {
+		if !getting.ValidTag(t) {
+			return ErrInvalidTag
+		}
+	}
+	c.Clozes = cloze.Faces(c.Desc)
 	return s.r.UpdateCard(g, c)
 }
@@ -31,8 +31,9 @@ func (r *repositoryStub) UpdateCard(g string, c Card) error {
 	}
 
 	for i := range r.cards {
-		if r.cards[i].Title == c.Title {
+		if (c.ID != "" && r.cards[i].ID == c.ID) || (c.ID == "" && r.cards[i].Title == c.Title) {
 			r.cards[i].Desc = c.Desc
+			r.cards[i].Clozes = c.Clozes
 			return nil
 		}
 	}
@@ -103,6 +104,20 @@ func TestUpdateCard(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name:  "Cloze",
+			group: "Group",
+			card:  Card{Title: "Subject1", Desc: "{{c2::Value}} {{c1::Value}}2"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "{{c2::Value}} {{c1::Value}}2", Clozes: []string{"c1", "c2"}},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
 		{
 			name:  "Card Not Found",
 			group: "Group",
@@ -117,6 +132,20 @@ func TestUpdateCard(t *testing.T) {
 			},
 			wantErr: errCardNotFound,
 		},
+		{
+			name:  "Invalid Tag",
+			group: "Group",
+			card:  Card{Title: "Subject1", Desc: "Value2", Tags: []string{"!old"}},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrInvalidTag,
+		},
 		{
 			name:  "Empty Title",
 			group: "Group",
@@ -149,3 +178,26 @@ func TestUpdateCard(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateCardByID(t *testing.T) {
+	repo := newRepositoryStubWithCards()
+	repo.cards = append(repo.cards, Card{ID: "9b29587ef2017333", Title: "Group.Subject3", Desc: "Value3"})
+	us := New(repo)
+
+	if err := us.UpdateCard("", Card{ID: "9b29587ef2017333", Desc: "Value4"}); err != nil {
+		t.Fatalf("Incorrect error. Want %v, got %v", nil, err)
+	}
+
+	want := []Card{
+		{Title: "Subject1", Desc: "Value1"},
+		{Title: "Subject2", Desc: "Value2"},
+		{Title: "Group.Subject1", Desc: "Value1"},
+		{Title: "Group.Subject2", Desc: "Value2"},
+		{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+		{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+		{ID: "9b29587ef2017333", Title: "Group.Subject3", Desc: "Value4"},
+	}
+	if !reflect.DeepEqual(want, repo.cards) {
+		t.Errorf("Incorrect repo.cards. Want %v, got %v", want, repo.cards)
+	}
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jmcveigh55/flash/pkg/core/attaching"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/storage"
+	"github.com/urfave/cli/v2"
+)
+
+func attachCmd(at attaching.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "attach",
+		Usage: "Attach a file, such as a diagram or screenshot, to a flashcard",
+		Action: func(ctx *cli.Context) error {
+			return attach(ctx, at)
+		},
+		ArgsUsage: "<card> <file>",
+	}
+}
+
+func gcCmd(at attaching.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "gc",
+		Usage: "Delete the media no flashcard has attached",
+		Action: func(ctx *cli.Context) error {
+			return removeUnusedMedia(at)
+		},
+	}
+}
+
+func attach(ctx *cli.Context, at attaching.Service) error {
+	path := ctx.Args().Get(1)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	group, title := storage.SplitPath(ctx.Args().Get(0))
+	m, err := at.Attach(
+		group,
+		attaching.Card{Title: title},
+		attaching.File{Name: filepath.Base(path), Data: data},
+	)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\tattached %s as %s\n", m.Name, m.File)
+	return nil
+}
+
+func removeUnusedMedia(at attaching.Service) error {
+	removed, err := at.RemoveUnusedMedia()
+	for _, f := range removed {
+		fmt.Printf("\tremoved %s\n", f)
+	}
+	if err == nil && len(removed) == 0 {
+		fmt.Println("\tno unused media")
+	}
+	return err
+}
+
+// formatMedia lists the names of the files attached to a card.
+func formatMedia(media []getting.Media) string {
+	names := make([]string, len(media))
+	for i, m := range media {
+		names[i] = m.Name
+	}
+	return strings.Join(names, ", ")
+}
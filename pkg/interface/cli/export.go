@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jmcveigh55/flash/pkg/core/exporting"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	exportCardsFile = "cards.json"
+	exportMediaDir  = "media"
+)
+
+func exportCmd(e exporting.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export the flashcards under the group, with their media, to a directory",
+		Action: func(ctx *cli.Context) error {
+			return export(ctx, e)
+		},
+		ArgsUsage: "[group]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "out",
+				Aliases:  []string{"o"},
+				Usage:    "Directory to export to",
+				Required: true,
+			},
+		},
+	}
+}
+
+// export writes the cards to a JSON file in the directory, and the media
+// attached to them to its media directory under the names they are stored
+// under, as the cards refer to them.
+func export(ctx *cli.Context, e exporting.Service) error {
+	c, err := e.Export(groupFromArgs(ctx.Args()))
+	if err != nil {
+		return err
+	}
+
+	dir := ctx.String("out")
+	mediaDir := filepath.Join(dir, exportMediaDir)
+	if err := os.MkdirAll(mediaDir, 0755); err != nil {
+		return err
+	}
+	for file, data := range c.Media {
+		if err := os.WriteFile(filepath.Join(mediaDir, file), data, 0644); err != nil {
+			return err
+		}
+	}
+
+	b, err := json.MarshalIndent(c.Cards, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, exportCardsFile), b, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("\texported %d card(s) and %d media file(s) to %s\n", len(c.Cards), len(c.Media), dir)
+	return nil
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/urfave/cli/v2"
+)
+
+func leechesCmd(g getting.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "leeches",
+		Usage: "List the leeches under the group, most lapses first",
+		Action: func(ctx *cli.Context) error {
+			return getLeeches(ctx, g)
+		},
+		ArgsUsage: "[group]",
+		Flags:     []cli.Flag{rawFlag()},
+	}
+}
+
+func getLeeches(ctx *cli.Context, g getting.Service) error {
+	group := groupFromArgs(ctx.Args())
+	cards, err := g.GetLeeches(group)
+	if err != nil {
+		return err
+	}
+
+	if len(cards) == 0 {
+		fmt.Println("\tno leeches")
+		return nil
+	}
+	md := rendererFromContext(ctx)
+	for i, c := range cards {
+		desc, body := renderContent(md, c.Desc)
+		fmt.Printf("\t%d) %s ->", i, c.Title)
+		if desc != "" {
+			fmt.Printf(" %s", desc)
+		}
+		fmt.Printf(" (%d lapses)", c.Lapses())
+		if c.Suspended {
+			fmt.Print(" [suspended]")
+		}
+		fmt.Println()
+		printBody(body)
+	}
+	return nil
+}
+
+// printLeech tells when a review has just made the card a leech.
+func printLeech(c reviewing.Card) {
+	if c.Leech {
+		fmt.Printf("\t%s is a leech after %d lapses, consider rewriting it\n", c.Title, c.State.Lapses)
+	}
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/logging"
+	"github.com/jmcveigh55/flash/pkg/storage"
+	"github.com/urfave/cli/v2"
+)
+
+const dateLayout = "2006-01-02"
+
+func logCmd(l logging.Service) *cli.Command {
+	return &cli.Command{
+		Name:    "log",
+		Aliases: []string{"l"},
+		Usage:   "Show the review log of the flashcards under the group",
+		Action: func(ctx *cli.Context) error {
+			return printLog(ctx, l)
+		},
+		ArgsUsage: "[group]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "title",
+				Aliases: []string{"t"},
+				Usage:   "Only show the reviews of this flashcard",
+			},
+			&cli.StringFlag{
+				Name:    "from",
+				Aliases: []string{"f"},
+				Usage:   "Only show reviews from this date (YYYY-MM-DD)",
+			},
+			&cli.StringFlag{
+				Name:  "to",
+				Usage: "Only show reviews before this date (YYYY-MM-DD)",
+			},
+		},
+	}
+}
+
+func printLog(ctx *cli.Context, l logging.Service) error {
+	q := logging.Query{Group: groupFromArgs(ctx.Args())}
+	if ctx.IsSet("title") {
+		group, title := cardPathFromContext(ctx)
+		q = logging.Query{Card: storage.JoinPath(group, title)}
+	}
+
+	var err error
+	if q.From, err = parseDate(ctx.String("from")); err != nil {
+		return err
+	}
+	if q.To, err = parseDate(ctx.String("to")); err != nil {
+		return err
+	}
+
+	reviews, err := l.GetReviews(q)
+	if err != nil {
+		return err
+	}
+	for _, r := range reviews {
+		fmt.Printf("\t%s %s (%s) %s in %s, %dd -> %dd (%s)\n",
+			r.Time.Local().Format("2006-01-02 15:04"), r.Card, r.Face, r.Grade,
+			r.Duration.Round(time.Second), r.LastInterval, r.Interval, r.Scheduler)
+	}
+	return nil
+}
+
+// parseDate parses a date in the local time zone. An empty string is the
+// zero time.
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(dateLayout, s, time.Local)
+}
@@ -0,0 +1,17 @@
+package cli
+
+import (
+	"github.com/jmcveigh55/flash/pkg/core/moving"
+	"github.com/urfave/cli/v2"
+)
+
+func moveCmd(m moving.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "mv",
+		Usage: "Rename or move a flashcard, or a group and everything under it",
+		Action: func(ctx *cli.Context) error {
+			return m.Move(ctx.Args().Get(0), ctx.Args().Get(1))
+		},
+		ArgsUsage: "<source> <destination>",
+	}
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jmcveigh55/flash/pkg/core/adding"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	errInvalidTemplate error = errors.New("template must be written name|front|back")
+	errInvalidField    error = errors.New("field must be written name=value")
+)
+
+// values collects every value of a repeated flag as given, without
+// splitting them on commas like a StringSliceFlag does.
+type values []string
+
+func (v *values) Set(s string) error {
+	*v = append(*v, s)
+	return nil
+}
+
+func (v *values) String() string {
+	return strings.Join(*v, ", ")
+}
+
+func noteTypeCmd(a adding.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "notetype",
+		Usage: "Add a note type, a list of fields and the flashcards made from them",
+		Action: func(ctx *cli.Context) error {
+			return addNoteType(ctx, a)
+		},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Aliases:  []string{"n"},
+				Usage:    "Note type's name",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "field",
+				Aliases:  []string{"f"},
+				Usage:    "Field's name, may be repeated or comma separated",
+				Required: true,
+			},
+			// Generic flags have no aliases, as they would be set again
+			// through each alias.
+			&cli.GenericFlag{
+				Name:     "template",
+				Usage:    "Flashcard made from each note, as name|front|back with {{Field}} placeholders, may be repeated",
+				Value:    &values{},
+				Required: true,
+			},
+		},
+	}
+}
+
+func noteCmd(a adding.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "note",
+		Usage: "Add a note, reviewed as a flashcard per template of its type",
+		Action: func(ctx *cli.Context) error {
+			return addNote(ctx, a)
+		},
+		ArgsUsage: "[group]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "title",
+				Aliases:  []string{"t"},
+				Usage:    "Note's title",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "type",
+				Aliases:  []string{"n"},
+				Usage:    "Note's type",
+				Required: true,
+			},
+			&cli.GenericFlag{
+				Name:  "field",
+				Usage: "Field's value, as name=value, may be repeated",
+				Value: &values{},
+			},
+			&cli.StringSliceFlag{
+				Name:    "tag",
+				Aliases: []string{"T"},
+				Usage:   "Tag the note, may be repeated or comma separated",
+			},
+		},
+	}
+}
+
+func addNoteType(ctx *cli.Context, a adding.Service) error {
+	t := adding.NoteType{
+		Name:   ctx.String("name"),
+		Fields: ctx.StringSlice("field"),
+	}
+	for _, v := range *ctx.Generic("template").(*values) {
+		parts := strings.SplitN(v, "|", 3)
+		if len(parts) != 3 {
+			return errInvalidTemplate
+		}
+		t.Templates = append(t.Templates, adding.Template{Name: parts[0], Front: parts[1], Back: parts[2]})
+	}
+	return a.AddNoteType(t)
+}
+
+func addNote(ctx *cli.Context, a adding.Service) error {
+	group, title := cardPathFromContext(ctx)
+	fields := map[string]string{}
+	for _, v := range *ctx.Generic("field").(*values) {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok {
+			return errInvalidField
+		}
+		fields[name] = value
+	}
+
+	return a.AddNote(group, adding.Note{
+		Title:  title,
+		Type:   ctx.String("type"),
+		Fields: fields,
+		Tags:   ctx.StringSlice("tag"),
+	})
+}
+
+// formatFields returns the note's fields as name=value, by name.
+func formatFields(n *getting.Note) string {
+	names := make([]string, 0, len(n.Fields))
+	for name := range n.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]string, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, fmt.Sprintf("%s=%s", name, n.Fields[name]))
+	}
+	return strings.Join(fields, ", ")
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jmcveigh55/flash/pkg/core/optimizing"
+	"github.com/urfave/cli/v2"
+)
+
+func optimizeCmd(o optimizing.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "optimize",
+		Usage: "Fit the FSRS weights of the group to its review log",
+		Action: func(ctx *cli.Context) error {
+			return optimize(ctx, o)
+		},
+		ArgsUsage: "[group]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "yes",
+				Aliases: []string{"y"},
+				Usage:   "Save the fitted weights without asking",
+			},
+		},
+	}
+}
+
+func optimize(ctx *cli.Context, o optimizing.Service) error {
+	group := groupFromArgs(ctx.Args())
+	r, err := o.Optimize(group)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Fitted to %d review(s)\n", r.Reviews)
+	fmt.Printf("\tdefault loss  %.4f\n", r.DefaultLoss)
+	fmt.Printf("\tfitted loss   %.4f\n", r.Loss)
+	fmt.Printf("\timprovement   %.1f%%\n", r.Improvement()*100)
+	fmt.Printf("Weights\n\t%s\n", formatFloats(r.Weights))
+
+	if !ctx.Bool("yes") {
+		fmt.Print("Save the weights to the group's settings? [y/N] ")
+		answer, err := newKeyReader(os.Stdin).ReadLine()
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(strings.TrimSpace(answer), "y") {
+			return nil
+		}
+	}
+	return o.SaveWeights(group, r.Weights)
+}
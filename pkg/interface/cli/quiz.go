@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jmcveigh55/flash/pkg/core/quizzing"
+	"github.com/urfave/cli/v2"
+)
+
+// maxChoices keeps every option selectable with a single key.
+const maxChoices = 9
+
+var errTooManyChoices = fmt.Errorf("a question can have at most %d choices", maxChoices)
+
+func quizCmd(q quizzing.Service) *cli.Command {
+	return &cli.Command{
+		Name:    "quiz",
+		Aliases: []string{"q"},
+		Usage:   "Answer multiple choice questions on the flashcards under the group",
+		Action: func(ctx *cli.Context) error {
+			return quiz(ctx, q)
+		},
+		ArgsUsage: "[group]",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:    "choices",
+				Aliases: []string{"n"},
+				Usage:   "Number of choices for each question",
+				Value:   quizzing.DefaultChoices,
+			},
+		},
+	}
+}
+
+func quiz(ctx *cli.Context, q quizzing.Service) error {
+	choices := ctx.Int("choices")
+	if choices > maxChoices {
+		return errTooManyChoices
+	}
+
+	group := groupFromArgs(ctx.Args())
+	quiz, err := q.Quiz(group, choices)
+	if errors.Is(err, quizzing.ErrNotEnoughChoices) {
+		fmt.Println("\tnot enough flashcards with different descriptions for a quiz")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	keys := newKeyReader(os.Stdin)
+	for {
+		question, ok := quiz.Next()
+		if !ok {
+			break
+		}
+
+		fmt.Printf("\n[%d left] %s\n", quiz.Remaining(), question.Title)
+		for i, o := range question.Options {
+			fmt.Printf("\t%d) %s\n", i+1, o)
+		}
+
+		option, quit, err := readOption(keys, len(question.Options))
+		if err != nil {
+			return err
+		}
+		if quit {
+			break
+		}
+
+		correct, err := quiz.Answer(option)
+		if err != nil {
+			return err
+		}
+		if correct {
+			fmt.Println("\tcorrect")
+		} else {
+			fmt.Printf("\tincorrect, it was %d) %s\n", question.Answer+1, question.Options[question.Answer])
+		}
+	}
+
+	printScore(quiz.Score())
+	return nil
+}
+
+// readOption prompts until an option's key is pressed, reporting whether the
+// user chose to quit instead.
+func readOption(keys *keyReader, n int) (int, bool, error) {
+	fmt.Printf("\tanswer: 1-%d q) quit ", n)
+	for {
+		k, err := keys.ReadKey()
+		if err != nil {
+			return 0, false, err
+		}
+		switch {
+		case k == 'q' || k == keyInterrupt:
+			fmt.Println()
+			return 0, true, nil
+		case k >= '1' && int(k-'1') < n:
+			fmt.Println()
+			return int(k - '1'), false, nil
+		}
+	}
+}
+
+func printScore(s quizzing.Score) {
+	percent := 0.0
+	if s.Total > 0 {
+		percent = float64(s.Correct) / float64(s.Total) * 100
+	}
+	fmt.Printf("\nScored %d/%d (%.0f%%)\n", s.Correct, s.Total, percent)
+	if s.Skipped > 0 {
+		fmt.Printf("\tskipped %d card(s) without enough different descriptions\n", s.Skipped)
+	}
+}
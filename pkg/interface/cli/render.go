@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jmcveigh55/flash/pkg/interface/markdown"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// bodyIndent indents card content printed under a card, taking up
+// bodyWidth columns.
+const (
+	bodyIndent = "\t    "
+	bodyWidth  = 12
+)
+
+// defaultWidth is used when the terminal's width cannot be found.
+const defaultWidth = 80
+
+func rawFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "raw",
+		Usage: "Print card content as written instead of rendering its Markdown",
+	}
+}
+
+// rawRenderer leaves card content as written.
+type rawRenderer struct{}
+
+func (rawRenderer) Render(s string) string {
+	return s
+}
+
+// rendererFromContext returns a renderer for card content printed under a
+// card, wrapping it to the terminal's width. Content is left as written when
+// --raw is passed or standard output is not a terminal.
+func rendererFromContext(ctx *cli.Context) markdown.Renderer {
+	fd := int(os.Stdout.Fd())
+	if ctx.Bool("raw") || !term.IsTerminal(fd) {
+		return rawRenderer{}
+	}
+	width, _, err := term.GetSize(fd)
+	if err != nil || width <= 0 {
+		width = defaultWidth
+	}
+	return markdown.New(width - bodyWidth)
+}
+
+// renderContent renders card content, returning it to print inline when it
+// fits on one line, or the lines to print under the card with printBody.
+func renderContent(md markdown.Renderer, s string) (string, []string) {
+	return splitContent(md.Render(s))
+}
+
+// splitContent returns rendered content to print inline when it is one line,
+// or its lines otherwise.
+func splitContent(s string) (string, []string) {
+	if !strings.Contains(s, "\n") {
+		return s, nil
+	}
+	return "", strings.Split(s, "\n")
+}
+
+// printBody prints card content under a card.
+func printBody(lines []string) {
+	for _, l := range lines {
+		if l == "" {
+			fmt.Println()
+			continue
+		}
+		fmt.Printf("%s%s\n", bodyIndent, l)
+	}
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jmcveigh55/flash/pkg/core/revising"
+	"github.com/jmcveigh55/flash/pkg/storage"
+	"github.com/urfave/cli/v2"
+)
+
+func historyCmd(rs revising.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "history",
+		Usage: "List the revisions of a flashcard's content, oldest first",
+		Action: func(ctx *cli.Context) error {
+			return getHistory(ctx, rs)
+		},
+		ArgsUsage: "<card>",
+	}
+}
+
+func diffCmd(rs revising.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "diff",
+		Usage: "Show the lines changed in a flashcard since a revision",
+		Action: func(ctx *cli.Context) error {
+			return diff(ctx, rs)
+		},
+		ArgsUsage: "<card> <revision>",
+	}
+}
+
+func revertCmd(rs revising.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "revert",
+		Usage: "Give a flashcard the content of an earlier revision back",
+		Action: func(ctx *cli.Context) error {
+			return revert(ctx, rs)
+		},
+		ArgsUsage: "<card> <revision>",
+	}
+}
+
+func getHistory(ctx *cli.Context, rs revising.Service) error {
+	group, title := storage.SplitPath(ctx.Args().Get(0))
+	history, err := rs.GetHistory(group, revising.Card{Title: title})
+	if err != nil {
+		return err
+	}
+
+	for _, r := range history {
+		desc, _, more := strings.Cut(r.Desc, "\n")
+		if more {
+			desc += " …"
+		}
+		fmt.Printf("\t%d) %s %s", r.Number, r.Time.Local().Format("2006-01-02 15:04"), desc)
+		if len(r.Answers) > 0 {
+			fmt.Printf(" (or %s)", strings.Join(r.Answers, ", "))
+		}
+		if r.Current {
+			fmt.Print(" [current]")
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+func diff(ctx *cli.Context, rs revising.Service) error {
+	n, err := revisionFromArgs(ctx.Args())
+	if err != nil {
+		return err
+	}
+	group, title := storage.SplitPath(ctx.Args().Get(0))
+	lines, err := rs.Diff(group, revising.Card{Title: title}, n)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range lines {
+		switch l.Op {
+		case revising.Insert:
+			fmt.Printf("\t\033[32m+ %s\033[0m\n", l.Text)
+		case revising.Delete:
+			fmt.Printf("\t\033[31m- %s\033[0m\n", l.Text)
+		default:
+			fmt.Printf("\t  %s\n", l.Text)
+		}
+	}
+	return nil
+}
+
+func revert(ctx *cli.Context, rs revising.Service) error {
+	n, err := revisionFromArgs(ctx.Args())
+	if err != nil {
+		return err
+	}
+	group, title := storage.SplitPath(ctx.Args().Get(0))
+	return rs.Revert(group, revising.Card{Title: title}, n)
+}
+
+// revisionFromArgs parses the revision number following the card.
+func revisionFromArgs(a cli.Args) (int, error) {
+	n, err := strconv.Atoi(a.Get(1))
+	if err != nil {
+		return 0, fmt.Errorf("invalid revision: %q", a.Get(1))
+	}
+	return n, nil
+}
@@ -2,12 +2,31 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jmcveigh55/flash/pkg/core/adding"
+	"github.com/jmcveigh55/flash/pkg/core/attaching"
+	"github.com/jmcveigh55/flash/pkg/core/burying"
+	"github.com/jmcveigh55/flash/pkg/core/cloze"
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
 	"github.com/jmcveigh55/flash/pkg/core/deleting"
+	"github.com/jmcveigh55/flash/pkg/core/exporting"
 	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/logging"
+	"github.com/jmcveigh55/flash/pkg/core/moving"
+	"github.com/jmcveigh55/flash/pkg/core/optimizing"
+	"github.com/jmcveigh55/flash/pkg/core/quizzing"
+	"github.com/jmcveigh55/flash/pkg/core/reporting"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/core/revising"
+	"github.com/jmcveigh55/flash/pkg/core/simulating"
+	"github.com/jmcveigh55/flash/pkg/core/studying"
+	"github.com/jmcveigh55/flash/pkg/core/suspending"
+	"github.com/jmcveigh55/flash/pkg/core/tagging"
 	"github.com/jmcveigh55/flash/pkg/core/updating"
+	"github.com/jmcveigh55/flash/pkg/interface/markdown"
 	"github.com/urfave/cli/v2"
 )
 
@@ -19,14 +38,46 @@ type service struct {
 	app *cli.App
 }
 
-func New(a adding.Service, d deleting.Service, g getting.Service, u updating.Service) *service {
+// Services are the core services the commands are built from.
+type Services struct {
+	Adding      adding.Service
+	Deleting    deleting.Service
+	Getting     getting.Service
+	Updating    updating.Service
+	Reviewing   reviewing.Service
+	Configuring configuring.Service
+	Studying    studying.Service
+	Quizzing    quizzing.Service
+	Logging     logging.Service
+	Reporting   reporting.Service
+	Suspending  suspending.Service
+	Burying     burying.Service
+	Simulating  simulating.Service
+	Optimizing  optimizing.Service
+	Moving      moving.Service
+	Tagging     tagging.Service
+	Attaching   attaching.Service
+	Revising    revising.Service
+	Exporting   exporting.Service
+}
+
+func New(s Services) *service {
 	return &service{
 		app: &cli.App{
 			Name:  "flash",
 			Usage: "a cli flashcard app",
 			Flags: []cli.Flag{},
 			Commands: []*cli.Command{
-				addCmd(a), deleteCmd(d), getCmd(g), getAllCmd(g), updateCmd(u),
+				addCmd(s.Adding), deleteCmd(s.Deleting), getCmd(s.Getting), getAllCmd(s.Getting),
+				updateCmd(s.Updating), reviewCmd(s.Reviewing), configCmd(s.Configuring),
+				studyCmd(s.Studying), quizCmd(s.Quizzing), logCmd(s.Logging), statsCmd(s.Reporting),
+				suspendCmd(s.Suspending), unsuspendCmd(s.Suspending), buryCmd(s.Burying),
+				leechesCmd(s.Getting), simulateCmd(s.Simulating), optimizeCmd(s.Optimizing),
+				moveCmd(s.Moving), tagCmd(s.Tagging), tagsCmd(s.Tagging), noteTypeCmd(s.Adding),
+				noteCmd(s.Adding), attachCmd(s.Attaching), gcCmd(s.Attaching),
+				historyCmd(s.Revising), diffCmd(s.Revising), revertCmd(s.Revising),
+				exportCmd(s.Exporting),
+				tuiCmd(s.Adding, s.Deleting, s.Getting, s.Updating, s.Studying),
 			},
 		},
 	}
@@ -58,6 +109,26 @@ func addCmd(a adding.Service) *cli.Command {
 				Usage:    "Flashcard's Description",
 				Required: true,
 			},
+			&cli.StringSliceFlag{
+				Name:    "answer",
+				Aliases: []string{"A"},
+				Usage:   "Alternative answer accepted when typing the description, may be repeated",
+			},
+			&cli.BoolFlag{
+				Name:    "reversible",
+				Aliases: []string{"r"},
+				Usage:   "Also review the flashcard from description to title",
+			},
+			&cli.BoolFlag{
+				Name:    "cloze",
+				Aliases: []string{"c"},
+				Usage:   "Review each {{cN::text::hint}} deletion in the description instead",
+			},
+			&cli.StringSliceFlag{
+				Name:    "tag",
+				Aliases: []string{"T"},
+				Usage:   "Tag the flashcard, may be repeated or comma separated",
+			},
 		},
 	}
 }
@@ -71,14 +142,7 @@ func deleteCmd(d deleting.Service) *cli.Command {
 			return deleteCard(ctx, d)
 		},
 		ArgsUsage: "[group]",
-		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:     "title",
-				Aliases:  []string{"t"},
-				Usage:    "Flashcard's title",
-				Required: true,
-			},
-		},
+		Flags:     cardFlags(),
 	}
 }
 
@@ -91,6 +155,7 @@ func getCmd(g getting.Service) *cli.Command {
 			return getCards(ctx, g)
 		},
 		ArgsUsage: "[group]",
+		Flags:     append(filterFlags(), rawFlag()),
 	}
 }
 
@@ -103,6 +168,14 @@ func getAllCmd(g getting.Service) *cli.Command {
 			return getAllCards(ctx, g)
 		},
 		ArgsUsage: "[group]",
+		Flags: append(filterFlags(),
+			&cli.IntFlag{
+				Name:    "box",
+				Aliases: []string{"b"},
+				Usage:   "Only get flashcards in this Leitner box",
+			},
+			rawFlag(),
+		),
 	}
 }
 
@@ -115,6 +188,52 @@ func updateCmd(u updating.Service) *cli.Command {
 			return updateCard(ctx, u)
 		},
 		ArgsUsage: "[group]",
+		Flags: append(cardFlags(),
+			&cli.StringFlag{
+				Name:     "description",
+				Aliases:  []string{"d"},
+				Usage:    "Flashcard's Description",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:    "answer",
+				Aliases: []string{"A"},
+				Usage:   "Alternative answer accepted when typing the description, may be repeated (replaces existing ones)",
+			},
+			&cli.StringSliceFlag{
+				Name:    "tag",
+				Aliases: []string{"T"},
+				Usage:   "Tag the flashcard, may be repeated or comma separated (replaces existing ones)",
+			},
+		),
+	}
+}
+
+// cardFlags find a flashcard by its title or by its ID.
+func cardFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "title",
+			Aliases: []string{"t"},
+			Usage:   "Flashcard's title",
+		},
+		&cli.StringFlag{
+			Name:    "id",
+			Aliases: []string{"i"},
+			Usage:   "Flashcard's ID, instead of its title",
+		},
+	}
+}
+
+func reviewCmd(r reviewing.Service) *cli.Command {
+	return &cli.Command{
+		Name:    "review",
+		Aliases: []string{"r"},
+		Usage:   "Grade a flashcard and schedule its next review",
+		Action: func(ctx *cli.Context) error {
+			return reviewCard(ctx, r)
+		},
+		ArgsUsage: "[group]",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:     "title",
@@ -123,49 +242,117 @@ func updateCmd(u updating.Service) *cli.Command {
 				Required: true,
 			},
 			&cli.StringFlag{
-				Name:     "description",
-				Aliases:  []string{"d"},
-				Usage:    "Flashcard's Description",
+				Name:     "grade",
+				Aliases:  []string{"g"},
+				Usage:    "Recall grade (again, hard, good, easy)",
 				Required: true,
 			},
+			&cli.BoolFlag{
+				Name:    "reverse",
+				Aliases: []string{"r"},
+				Usage:   "Grade the reverse, description to title, direction",
+			},
+			&cli.IntFlag{
+				Name:    "cloze",
+				Aliases: []string{"c"},
+				Usage:   "Grade the cloze deletions with this number",
+			},
+			&cli.StringFlag{
+				Name:    "template",
+				Aliases: []string{"p"},
+				Usage:   "Grade the note's flashcard made from this template",
+			},
 		},
 	}
 }
 
-func addCard(ctx *cli.Context, a adding.Service) error {
-	group := groupFromArgs(ctx.Args())
-	items := strings.Split(ctx.String("t"), ".")
-	title := items[len(items)-1]
-	if len(items) > 1 {
-		if group != "" {
-			group += "."
-		}
-		group += strings.Join(items[:1], ".")
+func configCmd(c configuring.Service) *cli.Command {
+	return &cli.Command{
+		Name:    "config",
+		Aliases: []string{"c"},
+		Usage:   "Show or change a group's settings",
+		Action: func(ctx *cli.Context) error {
+			return configGroup(ctx, c)
+		},
+		ArgsUsage: "[group]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "scheduler",
+				Aliases: []string{"s"},
+				Usage:   "Scheduler used to review the group's cards (sm2, fsrs, leitner)",
+			},
+			&cli.StringFlag{
+				Name:    "weights",
+				Aliases: []string{"w"},
+				Usage:   "Comma separated FSRS weights",
+			},
+			&cli.Float64Flag{
+				Name:    "retention",
+				Aliases: []string{"r"},
+				Usage:   "FSRS desired retention",
+			},
+			&cli.StringFlag{
+				Name:    "boxes",
+				Aliases: []string{"b"},
+				Usage:   "Comma separated review interval, in days, of each Leitner box",
+			},
+			&cli.IntFlag{
+				Name:    "new-per-day",
+				Aliases: []string{"n"},
+				Usage:   "Maximum new cards studied per day (-1 for unlimited, 0 to inherit)",
+			},
+			&cli.IntFlag{
+				Name:    "reviews-per-day",
+				Aliases: []string{"v"},
+				Usage:   "Maximum reviews studied per day (-1 for unlimited, 0 to inherit)",
+			},
+			&cli.IntFlag{
+				Name:    "leech-threshold",
+				Aliases: []string{"l"},
+				Usage:   fmt.Sprintf("Lapses that make a flashcard a leech (default %d, 0 to inherit)", reviewing.DefaultLeechThreshold),
+			},
+			&cli.StringFlag{
+				Name:    "leech-action",
+				Aliases: []string{"L"},
+				Usage:   "What is done to a leech (tag, suspend)",
+			},
+			&cli.StringFlag{
+				Name:    "target",
+				Aliases: []string{"T"},
+				Usage:   "Date, as YYYY-MM-DD, to have studied every card by (empty to inherit)",
+			},
+			&cli.IntFlag{
+				Name:    "target-reviews",
+				Aliases: []string{"N"},
+				Usage:   fmt.Sprintf("Reviews of each card wanted before the target date (default %d, 0 to inherit)", reviewing.DefaultTargetReviews),
+			},
+		},
 	}
+}
+
+func addCard(ctx *cli.Context, a adding.Service) error {
+	group, title := cardPathFromContext(ctx)
 
 	return a.AddCard(
 		group,
 		adding.Card{
-			Title: title,
-			Desc:  ctx.String("d"),
+			Title:      title,
+			Desc:       ctx.String("d"),
+			Answers:    ctx.StringSlice("answer"),
+			Reversible: ctx.Bool("r"),
+			Cloze:      ctx.Bool("cloze"),
+			Tags:       ctx.StringSlice("tag"),
 		},
 	)
 }
 
 func deleteCard(ctx *cli.Context, d deleting.Service) error {
-	group := groupFromArgs(ctx.Args())
-	items := strings.Split(ctx.String("t"), ".")
-	title := items[len(items)-1]
-	if len(items) > 1 {
-		if group != "" {
-			group += "."
-		}
-		group += strings.Join(items[:1], ".")
-	}
+	group, title := cardPathFromContext(ctx)
 
 	return d.DeleteCard(
 		group,
 		deleting.Card{
+			ID:    ctx.String("id"),
 			Title: title,
 		},
 	)
@@ -173,23 +360,306 @@ func deleteCard(ctx *cli.Context, d deleting.Service) error {
 
 func getCards(ctx *cli.Context, g getting.Service) error {
 	group := groupFromArgs(ctx.Args())
-	cards, err := g.GetCards(group)
-	for i, c := range cards {
-		fmt.Printf("\t%d) %s -> %s\n", i, c.Title, c.Desc)
+	q, err := queryFromContext(ctx)
+	if err != nil {
+		return err
 	}
+	cards, err := g.GetCards(group, filterFromContext(ctx))
+	printCards(q.Select(cards), rendererFromContext(ctx))
 	return err
 }
 
 func getAllCards(ctx *cli.Context, g getting.Service) error {
 	group := groupFromArgs(ctx.Args())
-	cards, err := g.GetAllCards(group)
-	for i, c := range cards {
-		fmt.Printf("\t%d) %s -> %s\n", i, c.Title, c.Desc)
+	q, err := queryFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	var cards []getting.Card
+	if ctx.IsSet("box") {
+		cards, err = g.GetCardsInBox(group, ctx.Int("box"), filterFromContext(ctx))
+	} else {
+		cards, err = g.GetAllCards(group, filterFromContext(ctx))
 	}
+	printCards(q.Select(cards), rendererFromContext(ctx))
 	return err
 }
 
+func filterFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "all",
+			Aliases: []string{"a"},
+			Usage:   "Also get suspended flashcards",
+		},
+		&cli.BoolFlag{
+			Name:    "suspended",
+			Aliases: []string{"s"},
+			Usage:   "Only get suspended flashcards",
+		},
+		tagQueryFlag(),
+	}
+}
+
+func filterFromContext(ctx *cli.Context) getting.Filter {
+	switch {
+	case ctx.Bool("suspended"):
+		return getting.Suspended
+	case ctx.Bool("all"):
+		return getting.All
+	}
+	return getting.Unsuspended
+}
+
+// printCards prints each card with an arrow showing the directions it is
+// reviewed in, or its cloze text or note fields, followed by any alternative
+// answers, tags, attachments and its ID. Leeches and suspended cards are
+// marked as such. A description rendered over more than one line is printed
+// under the card.
+func printCards(cards []getting.Card, md markdown.Renderer) {
+	for i, c := range cards {
+		arrow, desc := "->", c.Desc
+		var body []string
+		switch {
+		case c.Note != nil:
+			arrow, desc = "("+c.Note.Type+")", formatFields(c.Note)
+		default:
+			desc, body = renderContent(md, c.Desc)
+			if c.Cloze {
+				arrow = "(cloze)"
+			} else if c.Reversible {
+				arrow = "<->"
+			}
+		}
+		fmt.Printf("\t%d) %s %s", i, c.Title, arrow)
+		if desc != "" {
+			fmt.Printf(" %s", desc)
+		}
+		if len(c.Answers) > 0 {
+			fmt.Printf(" (or %s)", strings.Join(c.Answers, ", "))
+		}
+		if len(c.Tags) > 0 {
+			fmt.Printf(" (tags %s)", strings.Join(c.Tags, ", "))
+		}
+		if len(c.Media) > 0 {
+			fmt.Printf(" (attached %s)", formatMedia(c.Media))
+		}
+		if c.ID != "" {
+			fmt.Printf(" #%s", c.ID)
+		}
+		if c.Leech {
+			fmt.Print(" [leech]")
+		}
+		if c.Suspended {
+			fmt.Print(" [suspended]")
+		}
+		fmt.Println()
+		printBody(body)
+	}
+}
+
 func updateCard(ctx *cli.Context, u updating.Service) error {
+	group, title := cardPathFromContext(ctx)
+
+	return u.UpdateCard(
+		group,
+		updating.Card{
+			ID:      ctx.String("id"),
+			Title:   title,
+			Desc:    ctx.String("d"),
+			Answers: ctx.StringSlice("answer"),
+			Tags:    ctx.StringSlice("tag"),
+		},
+	)
+}
+
+func reviewCard(ctx *cli.Context, r reviewing.Service) error {
+	group, title := cardPathFromContext(ctx)
+
+	grade, err := reviewing.ParseGrade(ctx.String("g"))
+	if err != nil {
+		return err
+	}
+
+	face := getting.Forward
+	switch {
+	case ctx.IsSet("cloze"):
+		face = cloze.Face(ctx.Int("cloze"))
+	case ctx.IsSet("template"):
+		face = ctx.String("template")
+	case ctx.Bool("reverse"):
+		face = getting.Reverse
+	}
+
+	c, err := r.ReviewCard(group, reviewing.Card{Title: title, Face: face}, grade)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\t%s (%s) -> next review in %d day(s) (%s)\n", title, face, c.State.Interval, c.Due.Format("2006-01-02"))
+	printLeech(c)
+	return nil
+}
+
+func configGroup(ctx *cli.Context, c configuring.Service) error {
+	group := groupFromArgs(ctx.Args())
+	config, err := c.GetConfig(group)
+	if err != nil {
+		return err
+	}
+
+	if ctx.NumFlags() == 0 {
+		printConfig(config)
+		return nil
+	}
+
+	if ctx.IsSet("scheduler") {
+		config.Scheduler = ctx.String("scheduler")
+	}
+	if ctx.IsSet("weights") {
+		config.Weights, err = parseFloats(ctx.String("weights"))
+		if err != nil {
+			return err
+		}
+	}
+	if ctx.IsSet("retention") {
+		config.Retention = ctx.Float64("retention")
+	}
+	if ctx.IsSet("boxes") {
+		config.Boxes, err = parseInts(ctx.String("boxes"))
+		if err != nil {
+			return err
+		}
+	}
+	if ctx.IsSet("new-per-day") {
+		config.NewPerDay = ctx.Int("new-per-day")
+	}
+	if ctx.IsSet("reviews-per-day") {
+		config.ReviewsPerDay = ctx.Int("reviews-per-day")
+	}
+	if ctx.IsSet("leech-threshold") {
+		config.LeechThreshold = ctx.Int("leech-threshold")
+	}
+	if ctx.IsSet("leech-action") {
+		config.LeechAction = ctx.String("leech-action")
+	}
+	if ctx.IsSet("target") {
+		config.Target, err = parseDate(ctx.String("target"))
+		if err != nil {
+			return err
+		}
+	}
+	if ctx.IsSet("target-reviews") {
+		config.TargetReviews = ctx.Int("target-reviews")
+	}
+	return c.SetConfig(group, config)
+}
+
+func printConfig(c configuring.Config) {
+	fmt.Printf("\tscheduler:       %s\n", valueOrInherited(c.Scheduler))
+	fmt.Printf("\tweights:         %s\n", valueOrInherited(formatFloats(c.Weights)))
+	fmt.Printf("\tretention:       %s\n", valueOrInherited(formatFloat(c.Retention)))
+	fmt.Printf("\tboxes:           %s\n", valueOrInherited(formatInts(c.Boxes)))
+	fmt.Printf("\tnew per day:     %s\n", valueOrInherited(formatLimit(c.NewPerDay)))
+	fmt.Printf("\treviews per day: %s\n", valueOrInherited(formatLimit(c.ReviewsPerDay)))
+	fmt.Printf("\tleech threshold: %s\n", valueOrInherited(formatInt(c.LeechThreshold)))
+	fmt.Printf("\tleech action:    %s\n", valueOrInherited(c.LeechAction))
+	fmt.Printf("\ttarget:          %s\n", valueOrInherited(formatDate(c.Target)))
+	fmt.Printf("\ttarget reviews:  %s\n", valueOrInherited(formatInt(c.TargetReviews)))
+}
+
+func valueOrInherited(v string) string {
+	if v == "" {
+		return "(inherited)"
+	}
+	return v
+}
+
+func formatFloat(f float64) string {
+	if f == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func formatInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
+
+func formatLimit(n int) string {
+	switch n {
+	case 0:
+		return ""
+	case configuring.Unlimited:
+		return "unlimited"
+	}
+	return strconv.Itoa(n)
+}
+
+func formatFloats(fs []float64) string {
+	items := make([]string, len(fs))
+	for i, f := range fs {
+		items[i] = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return strings.Join(items, ",")
+}
+
+func formatInts(is []int) string {
+	items := make([]string, len(is))
+	for i, n := range is {
+		items[i] = strconv.Itoa(n)
+	}
+	return strings.Join(items, ",")
+}
+
+// parseInts parses a comma separated list of ints. An empty string clears
+// the list.
+func parseInts(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	items := strings.Split(s, ",")
+	is := make([]int, len(items))
+	for i, item := range items {
+		n, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+		is[i] = n
+	}
+	return is, nil
+}
+
+// parseFloats parses a comma separated list of floats. An empty string
+// clears the list.
+func parseFloats(s string) ([]float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	items := strings.Split(s, ",")
+	fs := make([]float64, len(items))
+	for i, item := range items {
+		f, err := strconv.ParseFloat(strings.TrimSpace(item), 64)
+		if err != nil {
+			return nil, err
+		}
+		fs[i] = f
+	}
+	return fs, nil
+}
+
+// cardPathFromContext splits the title flag into its group and title,
+// appending any groups in the title to the group argument.
+func cardPathFromContext(ctx *cli.Context) (string, string) {
 	group := groupFromArgs(ctx.Args())
 	items := strings.Split(ctx.String("t"), ".")
 	title := items[len(items)-1]
@@ -197,16 +667,9 @@ func updateCard(ctx *cli.Context, u updating.Service) error {
 		if group != "" {
 			group += "."
 		}
-		group += strings.Join(items[:1], ".")
+		group += strings.Join(items[:len(items)-1], ".")
 	}
-
-	return u.UpdateCard(
-		group,
-		updating.Card{
-			Title: title,
-			Desc:  ctx.String("d"),
-		},
-	)
+	return group, title
 }
 
 func groupFromArgs(a cli.Args) string {
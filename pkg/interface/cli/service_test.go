@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func TestCardPathFromContext(t *testing.T) {
+	cases := []struct {
+		name  string
+		args  []string
+		group string
+		title string
+	}{
+		{"Title", []string{"-t", "Title", "Group"}, "Group", "Title"},
+		{"Dotted Title", []string{"-t", "Group.Title"}, "Group", "Title"},
+		{"Nested Title", []string{"-t", "Group.SubGroup.Title"}, "Group.SubGroup", "Title"},
+		{"Nested Title In Group", []string{"-t", "SubGroup.Deeper.Title", "Group"}, "Group.SubGroup.Deeper", "Title"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			set := flag.NewFlagSet(c.name, flag.ContinueOnError)
+			set.String("t", "", "")
+			if err := set.Parse(c.args); err != nil {
+				t.Fatal(err)
+			}
+			group, title := cardPathFromContext(cli.NewContext(nil, set, nil))
+			if group != c.group {
+				t.Errorf("Incorrect group. Want %v, got %v", c.group, group)
+			}
+			if title != c.title {
+				t.Errorf("Incorrect title. Want %v, got %v", c.title, title)
+			}
+		})
+	}
+}
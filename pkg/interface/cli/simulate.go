@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/simulating"
+	"github.com/urfave/cli/v2"
+)
+
+const defaultSimulationDays = 30
+
+func simulateCmd(s simulating.Service) *cli.Command {
+	m := simulating.DefaultModel
+	return &cli.Command{
+		Name:  "simulate",
+		Usage: "Simulate studying the flashcards under the group, without changing them",
+		Action: func(ctx *cli.Context) error {
+			return simulate(ctx, s)
+		},
+		ArgsUsage: "[group]",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:    "days",
+				Aliases: []string{"d"},
+				Value:   defaultSimulationDays,
+				Usage:   "Number of days to simulate",
+			},
+			&cli.StringFlag{
+				Name:    "scheduler",
+				Aliases: []string{"s"},
+				Usage:   "Try another scheduler (sm2, fsrs, leitner)",
+			},
+			&cli.StringFlag{
+				Name:    "weights",
+				Aliases: []string{"w"},
+				Usage:   "Try other comma separated FSRS weights",
+			},
+			&cli.Float64Flag{
+				Name:    "retention",
+				Aliases: []string{"r"},
+				Usage:   "Try another FSRS desired retention",
+			},
+			&cli.StringFlag{
+				Name:    "boxes",
+				Aliases: []string{"b"},
+				Usage:   "Try other comma separated Leitner box intervals",
+			},
+			&cli.IntFlag{
+				Name:    "new-per-day",
+				Aliases: []string{"n"},
+				Usage:   "Try another new card limit (-1 for unlimited)",
+			},
+			&cli.IntFlag{
+				Name:    "reviews-per-day",
+				Aliases: []string{"v"},
+				Usage:   "Try another review limit (-1 for unlimited)",
+			},
+			&cli.Float64Flag{
+				Name:    "recall",
+				Aliases: []string{"R"},
+				Value:   m.Retention,
+				Usage:   "Chance of recalling a card on the day it is due",
+			},
+			&cli.Float64Flag{
+				Name:    "new-recall",
+				Aliases: []string{"N"},
+				Value:   m.NewRecall,
+				Usage:   "Chance of recalling a card studied for the first time",
+			},
+			&cli.Float64Flag{
+				Name:  "hard",
+				Value: m.Hard,
+				Usage: "Share of recalled cards graded hard",
+			},
+			&cli.Float64Flag{
+				Name:  "easy",
+				Value: m.Easy,
+				Usage: "Share of recalled cards graded easy",
+			},
+			&cli.DurationFlag{
+				Name:    "answer-time",
+				Aliases: []string{"T"},
+				Value:   m.AnswerTime,
+				Usage:   "Time taken to answer each card",
+			},
+			&cli.Int64Flag{
+				Name:  "seed",
+				Usage: "Seed for the random draws, to repeat a simulation",
+			},
+		},
+	}
+}
+
+func simulate(ctx *cli.Context, s simulating.Service) error {
+	group := groupFromArgs(ctx.Args())
+	o := simulating.Options{
+		Days: ctx.Int("days"),
+		Model: simulating.Model{
+			Retention:  ctx.Float64("recall"),
+			NewRecall:  ctx.Float64("new-recall"),
+			Hard:       ctx.Float64("hard"),
+			Easy:       ctx.Float64("easy"),
+			AnswerTime: ctx.Duration("answer-time"),
+		},
+		Seed: ctx.Int64("seed"),
+	}
+
+	var err error
+	o.Config.Scheduler = ctx.String("scheduler")
+	if o.Config.Weights, err = parseFloats(ctx.String("weights")); err != nil {
+		return err
+	}
+	o.Config.Retention = ctx.Float64("retention")
+	if o.Config.Boxes, err = parseInts(ctx.String("boxes")); err != nil {
+		return err
+	}
+	o.Config.NewPerDay = ctx.Int("new-per-day")
+	o.Config.ReviewsPerDay = ctx.Int("reviews-per-day")
+
+	result, err := s.Simulate(group, o)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range result.Days {
+		fmt.Printf("\t%s  new %-4d reviews %-5d forgotten %-4d %-9s retention %s\n",
+			d.Day.Format(dateLayout), d.New, d.Reviews, d.Forgotten,
+			d.Time.Round(time.Second), formatRetention(d))
+	}
+	total := result.Total()
+	fmt.Printf("Total\n\tnew %d, reviews %d, forgotten %d in %s\n",
+		total.New, total.Reviews, total.Forgotten, total.Time.Round(time.Second))
+	return nil
+}
+
+// formatRetention leaves out the retention of days before any card was
+// seen.
+func formatRetention(d simulating.Day) string {
+	if d.Retention == 0 && d.Reviews == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", d.Retention*100)
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/reporting"
+	"github.com/urfave/cli/v2"
+)
+
+// forecastWidth is the length of the longest bar in the due forecast.
+const forecastWidth = 40
+
+func statsCmd(r reporting.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "stats",
+		Usage: "Show statistics on the flashcards under the group",
+		Action: func(ctx *cli.Context) error {
+			return printStats(ctx, r)
+		},
+		ArgsUsage: "[group]",
+	}
+}
+
+func printStats(ctx *cli.Context, r reporting.Service) error {
+	group := groupFromArgs(ctx.Args())
+	s, err := r.GetStats(group)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Cards")
+	fmt.Printf("\tnew       %d\n", s.States.New)
+	fmt.Printf("\tlearning  %d\n", s.States.Learning)
+	fmt.Printf("\tmature    %d\n", s.States.Mature)
+
+	fmt.Println("Retention")
+	for _, rt := range s.Retention {
+		if rt.Reviews == 0 {
+			fmt.Printf("\t%-3d days  -\n", rt.Days)
+			continue
+		}
+		fmt.Printf("\t%-3d days  %.0f%% of %d review(s)\n", rt.Days, rt.Rate()*100, rt.Reviews)
+	}
+
+	fmt.Println("Streak")
+	fmt.Printf("\tcurrent   %d day(s)\n", s.Streak.Current)
+	fmt.Printf("\tlongest   %d day(s)\n", s.Streak.Longest)
+
+	fmt.Printf("Average answer time\n\t%s\n", s.AnswerTime.Round(100*time.Millisecond))
+
+	fmt.Println("Due forecast")
+	printForecast(s.Forecast)
+
+	if s.Plan != nil {
+		printPlan(*s.Plan)
+	}
+	return nil
+}
+
+func printPlan(p reporting.Plan) {
+	fmt.Printf("Target %s\n", p.Target.Format(dateLayout))
+	fmt.Printf("\tdays left %d\n", p.DaysLeft)
+	fmt.Printf("\tnew       %d per day\n", p.NewPerDay)
+	fmt.Printf("\treviews   %d per day\n", p.ReviewsPerDay)
+	if p.NewOverLimit() {
+		fmt.Printf("\twarning: the limit of %d new card(s) per day is too low to make the target\n", p.NewLimit)
+	}
+	if p.ReviewsOverLimit() {
+		fmt.Printf("\twarning: the limit of %d review(s) per day is too low to make the target\n", p.ReviewsLimit)
+	}
+}
+
+// printForecast draws a bar for each day's due count, scaled so the busiest
+// day fills forecastWidth.
+func printForecast(days []reporting.Due) {
+	most := 0
+	for _, d := range days {
+		if d.Faces > most {
+			most = d.Faces
+		}
+	}
+
+	for _, d := range days {
+		bar := 0
+		if most > 0 {
+			bar = (d.Faces*forecastWidth + most - 1) / most
+		}
+		fmt.Printf("\t%s | %s %d\n", d.Day.Format("01-02"), strings.Repeat("#", bar), d.Faces)
+	}
+}
@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jmcveigh55/flash/pkg/core/cloze"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/core/studying"
+	"github.com/jmcveigh55/flash/pkg/interface/markdown"
+	"github.com/urfave/cli/v2"
+)
+
+func studyCmd(s studying.Service) *cli.Command {
+	return &cli.Command{
+		Name:    "study",
+		Aliases: []string{"s"},
+		Usage:   "Study the due flashcards under the group",
+		Action: func(ctx *cli.Context) error {
+			return study(ctx, s)
+		},
+		ArgsUsage: "[group]",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "cram",
+				Aliases: []string{"c"},
+				Usage:   "Drill every flashcard, repeating missed ones until recalled",
+			},
+			&cli.StringFlag{
+				Name:    "order",
+				Aliases: []string{"o"},
+				Usage:   "Order to cram flashcards in (random, weakest)",
+				Value:   "random",
+			},
+			&cli.BoolFlag{
+				Name:    "keep-schedule",
+				Aliases: []string{"k"},
+				Usage:   "Cram without changing the flashcards' schedules",
+			},
+			&cli.BoolFlag{
+				Name:    "typed",
+				Aliases: []string{"a"},
+				Usage:   "Type each answer and have it checked",
+			},
+			&cli.StringFlag{
+				Name:    "ignore",
+				Aliases: []string{"i"},
+				Usage:   "Comma separated differences ignored when checking typed answers (case, space, diacritics)",
+				Value:   "case,space,diacritics",
+			},
+			tagQueryFlag(),
+			rawFlag(),
+		},
+	}
+}
+
+func study(ctx *cli.Context, s studying.Service) error {
+	session, err := newStudySession(ctx, s)
+	if err != nil {
+		return err
+	}
+	record := !ctx.Bool("cram") || !ctx.Bool("keep-schedule")
+	if session.Remaining() == 0 {
+		fmt.Println("\tno cards are due")
+		return nil
+	}
+
+	typed := ctx.Bool("typed")
+	options, err := parseMatchOptions(ctx.String("ignore"))
+	if err != nil {
+		return err
+	}
+
+	md := rendererFromContext(ctx)
+	keys := newKeyReader(os.Stdin)
+	for {
+		item, ok := session.Next()
+		if !ok {
+			break
+		}
+
+		prompt, body := renderContent(md, item.Prompt())
+		fmt.Printf("\n[%d left] (%s) %s\n", session.Remaining(), item.Face, prompt)
+		printBody(body)
+		var g reviewing.Grade
+		var quit bool
+		if typed {
+			g, quit, err = checkAnswer(keys, item, options)
+		} else {
+			g, quit, err = revealAnswer(keys, item, md)
+		}
+		if err != nil {
+			return err
+		}
+		if quit {
+			break
+		}
+
+		rc, err := session.Grade(g)
+		if err != nil {
+			return err
+		}
+		if record {
+			fmt.Printf("\t%s -> next review in %d day(s)\n", g, rc.State.Interval)
+			printLeech(rc)
+		}
+	}
+
+	printSummary(session.Summary())
+	return nil
+}
+
+func newStudySession(ctx *cli.Context, s studying.Service) (*studying.Session, error) {
+	group := groupFromArgs(ctx.Args())
+	q, err := queryFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ctx.Bool("cram") {
+		return s.Study(group, q)
+	}
+
+	order, err := studying.ParseOrder(ctx.String("order"))
+	if err != nil {
+		return nil, err
+	}
+	return s.Cram(group, q, studying.CramOptions{
+		Order:        order,
+		KeepSchedule: ctx.Bool("keep-schedule"),
+	})
+}
+
+// revealAnswer shows the item's answer once a key is pressed, then asks for
+// a grade, reporting whether the user chose to quit instead.
+func revealAnswer(keys *keyReader, item studying.Item, md markdown.Renderer) (reviewing.Grade, bool, error) {
+	fmt.Print("\t(press any key to reveal, q to quit)")
+	k, err := keys.ReadKey()
+	if err != nil {
+		return 0, false, err
+	}
+	if k == 'q' || k == keyInterrupt {
+		fmt.Println()
+		return 0, true, nil
+	}
+	answer, body := splitContent(formatAnswer(item, md))
+	fmt.Printf("\r\t%s\033[K\n", answer)
+	printBody(body)
+
+	fmt.Print("\tgrade: 1) again 2) hard 3) good 4) easy q) quit ")
+	return readGrade(keys, -1)
+}
+
+// formatAnswer returns the item's rendered answer, with the deletions of a
+// cloze face shown in bold.
+func formatAnswer(item studying.Item, md markdown.Renderer) string {
+	if !item.Card.Cloze {
+		return md.Render(item.Answer())
+	}
+	if _, ok := md.(rawRenderer); ok {
+		return cloze.Render(item.Card.Desc, item.Face, func(d cloze.Deletion) string {
+			return "\033[1m" + d.Text + "\033[0m"
+		})
+	}
+	return md.Render(cloze.Render(item.Card.Desc, item.Face, func(d cloze.Deletion) string {
+		return "**" + d.Text + "**"
+	}))
+}
+
+// checkAnswer reads a typed answer and shows how it differs from the
+// closest accepted answer, then asks to accept or override the suggested
+// grade, reporting whether the user chose to quit instead.
+func checkAnswer(keys *keyReader, item studying.Item, o studying.MatchOptions) (reviewing.Grade, bool, error) {
+	fmt.Print("\tanswer: ")
+	answer, err := keys.ReadLine()
+	if err != nil {
+		return 0, false, err
+	}
+
+	c := item.Check(answer, o)
+	fmt.Printf("\t%s\n", formatDiff(c.Diff))
+	if c.Similarity < 1 {
+		fmt.Printf("\texpected: %s\n", c.Expected)
+	}
+	fmt.Printf("\tmatch: %.0f%%\n", c.Similarity*100)
+
+	fmt.Printf("\tgrade: 1) again 2) hard 3) good 4) easy q) quit [enter: %s] ", c.Grade)
+	return readGrade(keys, c.Grade)
+}
+
+// readGrade waits for a grade key, reporting whether the user chose to quit
+// instead. Enter picks the suggested grade, if it is valid.
+func readGrade(keys *keyReader, suggested reviewing.Grade) (reviewing.Grade, bool, error) {
+	for {
+		k, err := keys.ReadKey()
+		if err != nil {
+			return 0, false, err
+		}
+		switch {
+		case k == 'q' || k == keyInterrupt:
+			fmt.Println()
+			return 0, true, nil
+		case k >= '1' && k <= '4':
+			fmt.Println()
+			return reviewing.Grade(k - '1'), false, nil
+		case (k == '\r' || k == '\n') && suggested.Valid():
+			fmt.Println()
+			return suggested, false, nil
+		}
+	}
+}
+
+// formatDiff colours text missing from a typed answer green and text that
+// should not have been typed red and struck through.
+func formatDiff(edits []studying.Edit) string {
+	var b strings.Builder
+	for _, e := range edits {
+		switch e.Op {
+		case studying.Insert:
+			fmt.Fprintf(&b, "\033[32m%s\033[0m", e.Text)
+		case studying.Delete:
+			fmt.Fprintf(&b, "\033[31;9m%s\033[0m", e.Text)
+		default:
+			b.WriteString(e.Text)
+		}
+	}
+	return b.String()
+}
+
+// parseMatchOptions parses a comma separated list of differences to ignore
+// when checking typed answers.
+func parseMatchOptions(s string) (studying.MatchOptions, error) {
+	var o studying.MatchOptions
+	for _, item := range strings.Split(s, ",") {
+		switch strings.TrimSpace(item) {
+		case "":
+		case "case":
+			o.IgnoreCase = true
+		case "space":
+			o.IgnoreSpace = true
+		case "diacritics":
+			o.IgnoreDiacritics = true
+		default:
+			return o, fmt.Errorf("unknown difference to ignore: %s", item)
+		}
+	}
+	return o, nil
+}
+
+func printSummary(s studying.Summary) {
+	fmt.Printf("\nReviewed %d card(s) in %s\n", s.Reviewed, s.Duration.Round(time.Second))
+	for g, n := range s.Grades {
+		fmt.Printf("\t%-5s %d\n", reviewing.Grade(g), n)
+	}
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"github.com/jmcveigh55/flash/pkg/core/burying"
+	"github.com/jmcveigh55/flash/pkg/core/suspending"
+	"github.com/urfave/cli/v2"
+)
+
+func suspendCmd(s suspending.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "suspend",
+		Usage: "Take a flashcard, or every flashcard under the group, out of study sessions",
+		Action: func(ctx *cli.Context) error {
+			if !ctx.IsSet("title") {
+				return s.SuspendGroup(groupFromArgs(ctx.Args()))
+			}
+			group, title := cardPathFromContext(ctx)
+			return s.SuspendCard(group, suspending.Card{Title: title})
+		},
+		ArgsUsage: "[group]",
+		Flags:     []cli.Flag{optionalTitleFlag()},
+	}
+}
+
+func unsuspendCmd(s suspending.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "unsuspend",
+		Usage: "Return a suspended flashcard, or every flashcard under the group, to study sessions",
+		Action: func(ctx *cli.Context) error {
+			if !ctx.IsSet("title") {
+				return s.UnsuspendGroup(groupFromArgs(ctx.Args()))
+			}
+			group, title := cardPathFromContext(ctx)
+			return s.UnsuspendCard(group, suspending.Card{Title: title})
+		},
+		ArgsUsage: "[group]",
+		Flags:     []cli.Flag{optionalTitleFlag()},
+	}
+}
+
+func buryCmd(b burying.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "bury",
+		Usage: "Leave a flashcard, or every flashcard under the group, out of study sessions until tomorrow",
+		Action: func(ctx *cli.Context) error {
+			if !ctx.IsSet("title") {
+				return b.BuryGroup(groupFromArgs(ctx.Args()))
+			}
+			group, title := cardPathFromContext(ctx)
+			return b.BuryCard(group, burying.Card{Title: title})
+		},
+		ArgsUsage: "[group]",
+		Flags:     []cli.Flag{optionalTitleFlag()},
+	}
+}
+
+func optionalTitleFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:    "title",
+		Aliases: []string{"t"},
+		Usage:   "Flashcard's title, the whole group when omitted",
+	}
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/tagging"
+	"github.com/urfave/cli/v2"
+)
+
+func tagCmd(t tagging.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "tag",
+		Usage: "Add or remove a flashcard's tags",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "add",
+				Usage: "Tag a flashcard",
+				Action: func(ctx *cli.Context) error {
+					group, c := tagCardFromContext(ctx)
+					return t.AddTags(group, c, ctx.StringSlice("tag"))
+				},
+				ArgsUsage: "[group]",
+				Flags:     tagFlags(),
+			},
+			{
+				Name:  "rm",
+				Usage: "Remove tags from a flashcard",
+				Action: func(ctx *cli.Context) error {
+					group, c := tagCardFromContext(ctx)
+					return t.RemoveTags(group, c, ctx.StringSlice("tag"))
+				},
+				ArgsUsage: "[group]",
+				Flags:     tagFlags(),
+			},
+		},
+	}
+}
+
+func tagsCmd(t tagging.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "tags",
+		Usage: "List the tags used under the group, with how many flashcards have each",
+		Action: func(ctx *cli.Context) error {
+			tags, err := t.GetTags(groupFromArgs(ctx.Args()))
+			if err != nil {
+				return err
+			}
+			for _, tag := range tags {
+				fmt.Printf("\t%s (%d)\n", tag.Name, tag.Count)
+			}
+			return nil
+		},
+		ArgsUsage: "[group]",
+	}
+}
+
+func tagFlags() []cli.Flag {
+	return append(cardFlags(),
+		&cli.StringSliceFlag{
+			Name:     "tag",
+			Aliases:  []string{"T"},
+			Usage:    "Tag, may be repeated or comma separated",
+			Required: true,
+		},
+	)
+}
+
+func tagCardFromContext(ctx *cli.Context) (string, tagging.Card) {
+	group, title := cardPathFromContext(ctx)
+	return group, tagging.Card{ID: ctx.String("id"), Title: title}
+}
+
+// tagQueryFlag selects flashcards by their tags.
+func tagQueryFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:    "tag",
+		Aliases: []string{"T"},
+		Usage:   "Only flashcards whose tags match the query, e.g. 'go+!old' or 'go,rust'",
+	}
+}
+
+func queryFromContext(ctx *cli.Context) (getting.Query, error) {
+	return getting.ParseQuery(ctx.String("tag"))
+}
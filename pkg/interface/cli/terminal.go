@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const keyInterrupt = 3 // Ctrl-C while the terminal is in raw mode
+
+// keyReader reads single key presses from a file. When the file is not a
+// terminal, e.g. when input is piped, it reads a line per key instead.
+type keyReader struct {
+	f *os.File
+	r *bufio.Reader
+}
+
+func newKeyReader(f *os.File) *keyReader {
+	return &keyReader{f, bufio.NewReader(f)}
+}
+
+func (k *keyReader) ReadKey() (byte, error) {
+	fd := int(k.f.Fd())
+	if term.IsTerminal(fd) {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return 0, err
+		}
+		defer term.Restore(fd, state)
+
+		b := make([]byte, 1)
+		if _, err := k.f.Read(b); err != nil {
+			return 0, err
+		}
+		return b[0], nil
+	}
+
+	line, err := k.r.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return 0, err
+	}
+	if len(line) == 0 {
+		return '\n', nil
+	}
+	return line[0], nil
+}
+
+// ReadLine reads a line of text, without its line ending.
+func (k *keyReader) ReadLine() (string, error) {
+	line, err := k.r.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
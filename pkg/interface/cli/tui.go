@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"github.com/jmcveigh55/flash/pkg/core/adding"
+	"github.com/jmcveigh55/flash/pkg/core/deleting"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/studying"
+	"github.com/jmcveigh55/flash/pkg/core/updating"
+	"github.com/jmcveigh55/flash/pkg/interface/tui"
+	"github.com/urfave/cli/v2"
+)
+
+func tuiCmd(a adding.Service, d deleting.Service, g getting.Service, u updating.Service, s studying.Service) *cli.Command {
+	return &cli.Command{
+		Name:  "tui",
+		Usage: "Browse, edit and study flashcards in a full-screen interface",
+		Action: func(ctx *cli.Context) error {
+			return tui.New(a, d, g, u).WithStudy(s).Run()
+		},
+	}
+}
@@ -0,0 +1,220 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// language describes enough of a programming language's syntax to colour
+// its keywords, strings, numbers and comments.
+type language struct {
+	keywords     map[string]bool
+	ignoreCase   bool
+	lineComments []string
+	blockComment [2]string
+	quotes       string
+}
+
+func words(s string) map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		m[w] = true
+	}
+	return m
+}
+
+var (
+	cLike = [2]string{"/*", "*/"}
+
+	golang = &language{
+		keywords: words(`break case chan const continue default defer else fallthrough
+			for func go goto if import interface map package range return select
+			struct switch type var true false nil iota`),
+		lineComments: []string{"//"},
+		blockComment: cLike,
+		quotes:       "\"'`",
+	}
+	c = &language{
+		keywords: words(`auto break case char const continue default do double else enum
+			extern float for goto if inline int long register return short signed
+			sizeof static struct switch typedef union unsigned void volatile while
+			bool true false NULL class namespace template typename public private
+			protected virtual new delete this nullptr using`),
+		lineComments: []string{"//"},
+		blockComment: cLike,
+		quotes:       "\"'",
+	}
+	java = &language{
+		keywords: words(`abstract boolean break byte case catch char class const continue
+			default do double else enum extends final finally float for if
+			implements import instanceof int interface long new package private
+			protected public return short static super switch this throw throws try
+			void volatile while true false null var record`),
+		lineComments: []string{"//"},
+		blockComment: cLike,
+		quotes:       "\"'",
+	}
+	javascript = &language{
+		keywords: words(`async await break case catch class const continue default delete
+			do else export extends finally for from function if import in instanceof
+			let new of return static super switch this throw try typeof var void
+			while yield true false null undefined interface type enum implements
+			public private readonly`),
+		lineComments: []string{"//"},
+		blockComment: cLike,
+		quotes:       "\"'`",
+	}
+	rust = &language{
+		keywords: words(`as async await break const continue crate dyn else enum extern
+			false fn for if impl in let loop match mod move mut pub ref return self
+			Self static struct super trait true type unsafe use where while`),
+		lineComments: []string{"//"},
+		blockComment: cLike,
+		quotes:       "\"",
+	}
+	python = &language{
+		keywords: words(`and as assert async await break class continue def del elif else
+			except False finally for from global if import in is lambda None
+			nonlocal not or pass raise return True try while with yield`),
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	}
+	shell = &language{
+		keywords: words(`if then else elif fi for while until do done case esac function
+			in return export local`),
+		lineComments: []string{"#"},
+		quotes:       "\"'",
+	}
+	sql = &language{
+		keywords: words(`select from where and or not insert into values update set delete
+			create table drop alter index join left right inner outer on group by
+			order having limit as null is in distinct primary key`),
+		ignoreCase:   true,
+		lineComments: []string{"--"},
+		blockComment: cLike,
+		quotes:       "'\"",
+	}
+	jsonLang = &language{
+		keywords: words(`true false null`),
+		quotes:   "\"",
+	}
+)
+
+var languages = map[string]*language{
+	"go":         golang,
+	"golang":     golang,
+	"c":          c,
+	"h":          c,
+	"cpp":        c,
+	"c++":        c,
+	"java":       java,
+	"javascript": javascript,
+	"js":         javascript,
+	"typescript": javascript,
+	"ts":         javascript,
+	"rust":       rust,
+	"rs":         rust,
+	"python":     python,
+	"py":         python,
+	"sh":         shell,
+	"bash":       shell,
+	"shell":      shell,
+	"zsh":        shell,
+	"sql":        sql,
+	"json":       jsonLang,
+}
+
+// Highlight colours the keywords, strings, numbers and comments of code in
+// the named language. Code in a language it does not know is left as is.
+func Highlight(lang, code string) string {
+	l, ok := languages[strings.ToLower(lang)]
+	if !ok {
+		return code
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(code); {
+		rest := code[i:]
+		r, size := utf8.DecodeRuneInString(rest)
+		switch {
+		case l.blockComment[0] != "" && strings.HasPrefix(rest, l.blockComment[0]):
+			n := len(l.blockComment[0])
+			if end := strings.Index(rest[n:], l.blockComment[1]); end >= 0 {
+				n += end + len(l.blockComment[1])
+			} else {
+				n = len(rest)
+			}
+			b.WriteString(grey + rest[:n] + colorOff)
+			i += n
+		case hasAnyPrefix(rest, l.lineComments):
+			n := strings.IndexByte(rest, '\n')
+			if n < 0 {
+				n = len(rest)
+			}
+			b.WriteString(grey + rest[:n] + colorOff)
+			i += n
+		case strings.ContainsRune(l.quotes, r):
+			n := quoted(rest, r)
+			b.WriteString(green + rest[:n] + colorOff)
+			i += n
+		case unicode.IsDigit(r):
+			n := strings.IndexFunc(rest, func(r rune) bool {
+				return !isIdent(r) && r != '.'
+			})
+			if n < 0 {
+				n = len(rest)
+			}
+			b.WriteString(magenta + rest[:n] + colorOff)
+			i += n
+		case isIdent(r):
+			n := strings.IndexFunc(rest, func(r rune) bool { return !isIdent(r) })
+			if n < 0 {
+				n = len(rest)
+			}
+			word := rest[:n]
+			if l.keywords[word] || l.ignoreCase && l.keywords[strings.ToLower(word)] {
+				word = blue + word + colorOff
+			}
+			b.WriteString(word)
+			i += n
+		default:
+			b.WriteString(rest[:size])
+			i += size
+		}
+	}
+	return b.String()
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// quoted returns the length of the string literal at the start of s, up to
+// its closing quote or, for an unclosed literal, the end of the line.
+func quoted(s string, quote rune) int {
+	escaped := false
+	for i, r := range s {
+		switch {
+		case i == 0:
+		case escaped:
+			escaped = false
+		case r == '\\' && quote != '`':
+			escaped = true
+		case r == quote:
+			return i + utf8.RuneLen(r)
+		case r == '\n' && quote != '`':
+			return i
+		}
+	}
+	return len(s)
+}
+
+func isIdent(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
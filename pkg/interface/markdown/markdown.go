@@ -0,0 +1,283 @@
+// Package markdown renders card content written in Markdown as text styled
+// with ANSI escape codes, for any interface adapter showing it in a terminal.
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/russross/blackfriday/v2"
+)
+
+// Renderer turns Markdown into styled text.
+type Renderer interface {
+	Render(string) string
+}
+
+const extensions = blackfriday.NoIntraEmphasis | blackfriday.Tables |
+	blackfriday.FencedCode | blackfriday.Autolink | blackfriday.Strikethrough |
+	blackfriday.SpaceHeadings | blackfriday.BackslashLineBreak
+
+// ANSI escape codes, each style ended by its own code so styles can nest.
+const (
+	bold, boldOff           = "\033[1m", "\033[22m"
+	dim, dimOff             = "\033[2m", "\033[22m"
+	italic, italicOff       = "\033[3m", "\033[23m"
+	underline, underlineOff = "\033[4m", "\033[24m"
+	strike, strikeOff       = "\033[9m", "\033[29m"
+	colorOff                = "\033[39m"
+	green                   = "\033[32m"
+	blue                    = "\033[34m"
+	magenta                 = "\033[35m"
+	cyan                    = "\033[36m"
+	grey                    = "\033[90m"
+)
+
+var escapeCode = regexp.MustCompile("\033\\[[0-9;]*m")
+
+type renderer struct {
+	width int
+}
+
+// New returns a renderer wrapping text to the width in columns, or not at
+// all if it is not positive.
+func New(width int) *renderer {
+	return &renderer{width: width}
+}
+
+// Render renders the Markdown, with a blank line between blocks and no
+// trailing newline.
+func (r *renderer) Render(src string) string {
+	doc := blackfriday.New(blackfriday.WithExtensions(extensions)).
+		Parse([]byte(strings.ReplaceAll(src, "\r\n", "\n")))
+	return strings.Join(r.blocks(doc, r.width, false), "\n")
+}
+
+// blocks renders the children of a node as lines no wider than width,
+// separated by blank lines unless tight.
+func (r *renderer) blocks(n *blackfriday.Node, width int, tight bool) []string {
+	var lines []string
+	for c := n.FirstChild; c != nil; c = c.Next {
+		if len(lines) > 0 && !tight {
+			lines = append(lines, "")
+		}
+		lines = append(lines, r.block(c, width)...)
+	}
+	return lines
+}
+
+func (r *renderer) block(n *blackfriday.Node, width int) []string {
+	switch n.Type {
+	case blackfriday.Paragraph:
+		return wrap(inline(n), width)
+	case blackfriday.Heading:
+		text := bold + inline(n) + boldOff
+		if n.Level == 1 {
+			text = underline + text + underlineOff
+		}
+		return wrap(text, width)
+	case blackfriday.HorizontalRule:
+		w := width
+		if w <= 0 || w > 40 {
+			w = 40
+		}
+		return []string{dim + strings.Repeat("─", w) + dimOff}
+	case blackfriday.CodeBlock:
+		code := strings.TrimSuffix(string(n.Literal), "\n")
+		lang, _, _ := strings.Cut(string(n.Info), " ")
+		return indent(strings.Split(Highlight(lang, code), "\n"), "  ", "  ")
+	case blackfriday.BlockQuote:
+		bar := dim + "│ " + dimOff
+		return indent(r.blocks(n, width-2, false), bar, bar)
+	case blackfriday.List:
+		return r.list(n, width)
+	case blackfriday.Table:
+		return table(n)
+	case blackfriday.HTMLBlock:
+		return strings.Split(strings.TrimSuffix(string(n.Literal), "\n"), "\n")
+	}
+	return wrap(inline(n), width)
+}
+
+// list renders each item of a list after its bullet or number, with the
+// item's further lines lined up under its first.
+func (r *renderer) list(n *blackfriday.Node, width int) []string {
+	var lines []string
+	i := 1
+	for item := n.FirstChild; item != nil; item = item.Next {
+		marker := "• "
+		if n.ListFlags&blackfriday.ListTypeOrdered != 0 {
+			marker = fmt.Sprintf("%d. ", i)
+		}
+		if len(lines) > 0 && !n.Tight {
+			lines = append(lines, "")
+		}
+		pad := strings.Repeat(" ", runewidth.StringWidth(marker))
+		body := r.blocks(item, width-len(pad), n.Tight)
+		lines = append(lines, indent(body, marker, pad)...)
+		i++
+	}
+	return lines
+}
+
+// table renders a table's rows with their cells lined up in columns.
+func table(n *blackfriday.Node) []string {
+	var rows [][]string
+	var widths []int
+	var head int
+	n.Walk(func(c *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		switch {
+		case !entering:
+		case c.Type == blackfriday.TableRow:
+			rows = append(rows, nil)
+		case c.Type == blackfriday.TableCell:
+			text := inline(c)
+			if c.IsHeader {
+				text = bold + text + boldOff
+				head = len(rows)
+			}
+			row := len(rows) - 1
+			col := len(rows[row])
+			rows[row] = append(rows[row], text)
+			if col == len(widths) {
+				widths = append(widths, 0)
+			}
+			if w := Width(text); w > widths[col] {
+				widths[col] = w
+			}
+			return blackfriday.SkipChildren
+		}
+		return blackfriday.GoToNext
+	})
+
+	var lines []string
+	for i, row := range rows {
+		cells := make([]string, len(row))
+		for j, cell := range row {
+			cells[j] = cell + strings.Repeat(" ", widths[j]-Width(cell))
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(cells, " │ "), " "))
+		if i+1 == head {
+			rule := make([]string, len(widths))
+			for j, w := range widths {
+				rule[j] = strings.Repeat("─", w)
+			}
+			lines = append(lines, strings.Join(rule, "─┼─"))
+		}
+	}
+	return lines
+}
+
+// inline renders the text and inline styles under a node on one line, apart
+// from hard line breaks.
+func inline(n *blackfriday.Node) string {
+	var b strings.Builder
+	n.Walk(func(c *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		if c == n {
+			return blackfriday.GoToNext
+		}
+		switch c.Type {
+		case blackfriday.Text:
+			b.WriteString(strings.ReplaceAll(string(c.Literal), "\n", " "))
+		case blackfriday.Softbreak:
+			b.WriteString(" ")
+		case blackfriday.Hardbreak:
+			b.WriteString("\n")
+		case blackfriday.Code:
+			b.WriteString(cyan + string(c.Literal) + colorOff)
+		case blackfriday.HTMLSpan:
+			b.Write(c.Literal)
+		case blackfriday.Emph:
+			b.WriteString(toggle(entering, italic, italicOff))
+		case blackfriday.Strong:
+			b.WriteString(toggle(entering, bold, boldOff))
+		case blackfriday.Del:
+			b.WriteString(toggle(entering, strike, strikeOff))
+		case blackfriday.Link:
+			b.WriteString(toggle(entering, underline, underlineOff))
+			if !entering && string(c.Destination) != linkText(c) {
+				b.WriteString(" " + dim + "(" + string(c.Destination) + ")" + dimOff)
+			}
+		case blackfriday.Image:
+			if entering {
+				b.WriteString(dim + "[image: " + linkText(c) + "]" + dimOff)
+			}
+			return blackfriday.SkipChildren
+		}
+		return blackfriday.GoToNext
+	})
+	return b.String()
+}
+
+func toggle(entering bool, on, off string) string {
+	if entering {
+		return on
+	}
+	return off
+}
+
+// linkText returns the plain text of a link or image.
+func linkText(n *blackfriday.Node) string {
+	var b strings.Builder
+	n.Walk(func(c *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		if entering && (c.Type == blackfriday.Text || c.Type == blackfriday.Code) {
+			b.Write(c.Literal)
+		}
+		return blackfriday.GoToNext
+	})
+	return b.String()
+}
+
+// indent prefixes the first line with first and every other line with rest.
+func indent(lines []string, first, rest string) []string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+		out[i] = strings.TrimRight(prefix+l, " ")
+	}
+	return out
+}
+
+// wrap breaks text into lines no wider than width at spaces, keeping any
+// word wider than width whole. Line breaks in the text are kept.
+func wrap(text string, width int) []string {
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		if width <= 0 {
+			lines = append(lines, para)
+			continue
+		}
+		var line string
+		var w int
+		for _, word := range strings.Fields(para) {
+			ww := Width(word)
+			switch {
+			case line == "":
+				line, w = word, ww
+			case w+1+ww > width:
+				lines = append(lines, line)
+				line, w = word, ww
+			default:
+				line, w = line+" "+word, w+1+ww
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Width returns how many columns the text takes up in a terminal, ignoring
+// ANSI escape codes.
+func Width(s string) int {
+	return runewidth.StringWidth(Strip(s))
+}
+
+// Strip removes the ANSI escape codes from the text.
+func Strip(s string) string {
+	return escapeCode.ReplaceAllString(s, "")
+}
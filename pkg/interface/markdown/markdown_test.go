@@ -0,0 +1,255 @@
+package markdown
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		width int
+		want  string
+	}{
+		{
+			name: "plain text",
+			src:  "A desc.",
+			want: "A desc.",
+		},
+		{
+			name: "inline styles",
+			src:  "*a* **b** ~~c~~ `d`",
+			want: "\033[3ma\033[23m \033[1mb\033[22m \033[9mc\033[29m \033[36md\033[39m",
+		},
+		{
+			name: "nested styles",
+			src:  "**a *b* c**",
+			want: "\033[1ma \033[3mb\033[23m c\033[22m",
+		},
+		{
+			name: "heading",
+			src:  "## Go",
+			want: "\033[1mGo\033[22m",
+		},
+		{
+			name: "link",
+			src:  "[docs](https://go.dev)",
+			want: "\033[4mdocs\033[24m \033[2m(https://go.dev)\033[22m",
+		},
+		{
+			name: "autolink",
+			src:  "https://go.dev",
+			want: "\033[4mhttps://go.dev\033[24m",
+		},
+		{
+			name: "paragraphs",
+			src:  "one\ntwo\n\nthree",
+			want: "one two\n\nthree",
+		},
+		{
+			name: "hard break",
+			src:  "one\\\ntwo",
+			want: "one\ntwo",
+		},
+		{
+			name:  "wrapped",
+			src:   "the quick brown fox jumps",
+			width: 10,
+			want:  "the quick\nbrown fox\njumps",
+		},
+		{
+			name:  "wrapped styles",
+			src:   "the **quick brown** fox",
+			width: 10,
+			want:  "the \033[1mquick\nbrown\033[22m fox",
+		},
+		{
+			name: "bullet list",
+			src:  "- a\n- b\n  - c",
+			want: "• a\n• b\n  • c",
+		},
+		{
+			name: "ordered list",
+			src:  "1. a\n1. b",
+			want: "1. a\n2. b",
+		},
+		{
+			name:  "wrapped list",
+			src:   "- the quick brown fox",
+			width: 11,
+			want:  "• the quick\n  brown fox",
+		},
+		{
+			name: "block quote",
+			src:  "> a\n>\n> b",
+			want: "\033[2m│ \033[22ma\n\033[2m│ \033[22m\n\033[2m│ \033[22mb",
+		},
+		{
+			name: "code block",
+			src:  "```\nx := 1\n\ty\n```",
+			want: "  x := 1\n  \ty",
+		},
+		{
+			name: "highlighted code block",
+			src:  "```go\nreturn\n```",
+			want: "  \033[34mreturn\033[39m",
+		},
+		{
+			name: "table",
+			src:  "| a | b |\n|---|---|\n| 1 | 22 |",
+			want: "\033[1ma\033[22m │ \033[1mb\033[22m\n──┼───\n1 │ 22",
+		},
+		{
+			name:  "rule",
+			src:   "---",
+			width: 5,
+			want:  "\033[2m─────\033[22m",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := New(test.width).Render(test.src)
+			if got != test.want {
+				t.Errorf("Incorrect render. Want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRenderWidth(t *testing.T) {
+	src := "A long paragraph of **styled** text that has to be wrapped, with `code` and " +
+		"a [link](https://go.dev) in it.\n\n- a list item that is also too long to fit\n\n> and a quote after it"
+	for _, width := range []int{16, 20, 40} {
+		for _, line := range strings.Split(New(width).Render(src), "\n") {
+			if w := Width(line); w > width {
+				t.Errorf("Incorrect line width. Want at most %d, got %d: %q", width, w, Strip(line))
+			}
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name string
+		lang string
+		code string
+		want string
+	}{
+		{
+			name: "unknown language",
+			lang: "brainfuck",
+			code: "if 1",
+			want: "if 1",
+		},
+		{
+			name: "keywords",
+			lang: "go",
+			code: "if x {",
+			want: "\033[34mif\033[39m x {",
+		},
+		{
+			name: "keyword in identifier",
+			lang: "go",
+			code: "iffy",
+			want: "iffy",
+		},
+		{
+			name: "case insensitive keywords",
+			lang: "SQL",
+			code: "SELECT a",
+			want: "\033[34mSELECT\033[39m a",
+		},
+		{
+			name: "strings",
+			lang: "go",
+			code: `"a\"b" + 'c'`,
+			want: "\033[32m\"a\\\"b\"\033[39m + \033[32m'c'\033[39m",
+		},
+		{
+			name: "unclosed string",
+			lang: "js",
+			code: "'a\nb",
+			want: "\033[32m'a\033[39m\nb",
+		},
+		{
+			name: "raw string",
+			lang: "go",
+			code: "`a\\`",
+			want: "\033[32m`a\\`\033[39m",
+		},
+		{
+			name: "numbers",
+			lang: "python",
+			code: "x1 = 0x1f + 2.5",
+			want: "x1 = \033[35m0x1f\033[39m + \033[35m2.5\033[39m",
+		},
+		{
+			name: "line comment",
+			lang: "sh",
+			code: "ls # list\nls",
+			want: "ls \033[90m# list\033[39m\nls",
+		},
+		{
+			name: "block comment",
+			lang: "c",
+			code: "/* a\nb */ int",
+			want: "\033[90m/* a\nb */\033[39m \033[34mint\033[39m",
+		},
+		{
+			name: "string in comment",
+			lang: "go",
+			code: `// "a"`,
+			want: "\033[90m// \"a\"\033[39m",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Highlight(test.lang, test.code)
+			if got != test.want {
+				t.Errorf("Incorrect highlight. Want %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"\033[1mabc\033[22m", 3},
+		{"猫", 2},
+	}
+
+	for _, test := range tests {
+		if got := Width(test.s); got != test.want {
+			t.Errorf("Incorrect width of %q. Want %d, got %d", test.s, test.want, got)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"a b c", 0, []string{"a b c"}},
+		{"a b c", 3, []string{"a b", "c"}},
+		{"abcdef g", 3, []string{"abcdef", "g"}},
+		{"a\nb", 10, []string{"a", "b"}},
+		{"", 10, []string{""}},
+	}
+
+	for _, test := range tests {
+		if got := wrap(test.text, test.width); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Incorrect wrap of %q. Want %q, got %q", test.text, test.want, got)
+		}
+	}
+}
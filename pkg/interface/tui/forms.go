@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/jmcveigh55/flash/pkg/core/adding"
+	"github.com/jmcveigh55/flash/pkg/core/deleting"
+	"github.com/jmcveigh55/flash/pkg/core/updating"
+	"github.com/jmcveigh55/flash/pkg/storage"
+	"github.com/rivo/tview"
+)
+
+// modal centers the primitive over the main page.
+func modal(p tview.Primitive, width, height int) tview.Primitive {
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p, height, 1, true).
+			AddItem(nil, 0, 1, false), width, 1, true).
+		AddItem(nil, 0, 1, false)
+}
+
+func (t *service) showForm(title string, f *tview.Form) {
+	f.SetBorder(true).SetTitle(" " + title + " ")
+	f.SetCancelFunc(t.closeForm)
+	t.pages.AddPage(formPage, modal(f, 60, 13), true, true)
+	t.app.SetFocus(f)
+}
+
+func (t *service) closeForm() {
+	t.pages.RemovePage(formPage)
+	t.app.SetFocus(t.tree)
+}
+
+func (t *service) showAddForm() {
+	f := tview.NewForm().
+		AddInputField("Group", t.group, 40, nil, nil).
+		AddInputField("Title", "", 40, nil, nil).
+		AddInputField("Description", "", 40, nil, nil).
+		AddCheckbox("Reversible", false, nil)
+	f.AddButton("Add", func() {
+		group := f.GetFormItemByLabel("Group").(*tview.InputField).GetText()
+		err := t.a.AddCard(group, adding.Card{
+			Title:      f.GetFormItemByLabel("Title").(*tview.InputField).GetText(),
+			Desc:       f.GetFormItemByLabel("Description").(*tview.InputField).GetText(),
+			Reversible: f.GetFormItemByLabel("Reversible").(*tview.Checkbox).IsChecked(),
+		})
+		t.setError(err)
+		if err == nil {
+			t.group = group
+			t.closeForm()
+			t.refresh()
+		}
+	})
+	f.AddButton("Cancel", t.closeForm)
+	t.showForm("Add Card", f)
+}
+
+func (t *service) showEditForm() {
+	c, ok := t.currentCard(t.list.GetCurrentItem())
+	if !ok {
+		return
+	}
+
+	_, title := storage.SplitPath(c.Title)
+	f := tview.NewForm().
+		AddInputField("Description", c.Desc, 40, nil, nil)
+	f.AddButton("Save", func() {
+		err := t.u.UpdateCard(t.group, updating.Card{
+			Title: title,
+			Desc:  f.GetFormItemByLabel("Description").(*tview.InputField).GetText(),
+		})
+		t.setError(err)
+		if err == nil {
+			t.closeForm()
+			t.refresh()
+		}
+	})
+	f.AddButton("Cancel", t.closeForm)
+	t.showForm("Edit Description of "+title, f)
+}
+
+func (t *service) showDeleteModal() {
+	c, ok := t.currentCard(t.list.GetCurrentItem())
+	if !ok {
+		return
+	}
+
+	m := tview.NewModal().
+		SetText(fmt.Sprintf("Delete %s?", c.Title)).
+		AddButtons([]string{"Delete", "Cancel"}).
+		SetDoneFunc(func(_ int, label string) {
+			if label == "Delete" {
+				_, title := storage.SplitPath(c.Title)
+				t.setError(t.d.DeleteCard(t.group, deleting.Card{Title: title}))
+				t.refresh()
+			}
+			t.closeForm()
+		})
+	t.pages.AddPage(formPage, m, true, true)
+	t.app.SetFocus(m)
+}
@@ -0,0 +1,195 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jmcveigh55/flash/pkg/core/adding"
+	"github.com/jmcveigh55/flash/pkg/core/deleting"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/studying"
+	"github.com/jmcveigh55/flash/pkg/core/updating"
+	"github.com/jmcveigh55/flash/pkg/storage"
+	"github.com/rivo/tview"
+)
+
+const (
+	mainPage  = "main"
+	formPage  = "form"
+	studyPage = "study"
+
+	help = "[::b]a[::-] add  [::b]e[::-] edit description  [::b]d[::-] delete  [::b]s[::-] study  [::b]tab[::-] switch pane  [::b]q[::-] quit"
+)
+
+type Service interface {
+	Run() error
+}
+
+type service struct {
+	a adding.Service
+	d deleting.Service
+	g getting.Service
+	u updating.Service
+	s studying.Service
+
+	app    *tview.Application
+	pages  *tview.Pages
+	tree   *tview.TreeView
+	list   *tview.List
+	detail *tview.TextView
+	status *tview.TextView
+
+	group string
+	cards []getting.Card
+}
+
+func New(a adding.Service, d deleting.Service, g getting.Service, u updating.Service) *service {
+	t := &service{a: a, d: d, g: g, u: u}
+
+	t.tree = tview.NewTreeView()
+	t.tree.SetBorder(true).SetTitle(" Groups ")
+	t.tree.SetChangedFunc(func(n *tview.TreeNode) {
+		t.selectGroup(n.GetReference().(string))
+	})
+
+	t.list = tview.NewList().ShowSecondaryText(false)
+	t.list.SetBorder(true).SetTitle(" Cards ")
+	t.list.SetChangedFunc(func(i int, _, _ string, _ rune) {
+		t.showCard(i)
+	})
+
+	t.detail = tview.NewTextView().SetWrap(true).SetWordWrap(true).SetDynamicColors(true)
+	t.detail.SetBorder(true).SetTitle(" Card ")
+
+	t.status = tview.NewTextView().SetDynamicColors(true).SetText(help)
+
+	right := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(t.list, 0, 1, false).
+		AddItem(t.detail, 0, 1, false)
+	body := tview.NewFlex().
+		AddItem(t.tree, 0, 1, true).
+		AddItem(right, 0, 2, false)
+	main := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(body, 0, 1, true).
+		AddItem(t.status, 1, 0, false)
+
+	t.pages = tview.NewPages().AddPage(mainPage, main, true, true)
+	t.app = tview.NewApplication().SetRoot(t.pages, true)
+	main.SetInputCapture(t.handleMainKey)
+
+	t.refresh()
+	return t
+}
+
+// WithStudy enables the study screen, which schedules the cards it shows and
+// so needs the studying service on top of the services the browser uses.
+func (t *service) WithStudy(s studying.Service) *service {
+	t.s = s
+	return t
+}
+
+func (t *service) Run() error {
+	return t.app.Run()
+}
+
+func (t *service) handleMainKey(ev *tcell.EventKey) *tcell.EventKey {
+	switch ev.Key() {
+	case tcell.KeyTab, tcell.KeyBacktab:
+		if t.tree.HasFocus() {
+			t.app.SetFocus(t.list)
+		} else {
+			t.app.SetFocus(t.tree)
+		}
+		return nil
+	case tcell.KeyRune:
+	default:
+		return ev
+	}
+
+	switch ev.Rune() {
+	case 'a':
+		t.showAddForm()
+	case 'e':
+		t.showEditForm()
+	case 'd':
+		t.showDeleteModal()
+	case 's':
+		t.showStudy()
+	case 'q':
+		t.app.Stop()
+	default:
+		return ev
+	}
+	return nil
+}
+
+// refresh rebuilds the group tree and reloads the selected group's cards.
+func (t *service) refresh() {
+	cards, err := t.g.GetAllCards("", getting.All)
+	if err != nil {
+		cards = nil
+	}
+
+	root := newGroupTree(cards)
+	t.tree.SetRoot(root)
+	if n := findGroup(root, t.group); n != nil {
+		t.tree.SetCurrentNode(n)
+	} else {
+		t.tree.SetCurrentNode(root)
+		t.group = ""
+	}
+	t.selectGroup(t.group)
+}
+
+func (t *service) selectGroup(g string) {
+	t.group = g
+	t.cards, _ = t.g.GetCards(g, getting.All)
+
+	current := t.list.GetCurrentItem()
+	t.list.Clear()
+	for _, c := range t.cards {
+		_, title := storage.SplitPath(c.Title)
+		if c.Reversible {
+			title += " <->"
+		}
+		if c.Suspended {
+			title += " (suspended)"
+		}
+		t.list.AddItem(title, "", 0, nil)
+	}
+	if current < len(t.cards) {
+		t.list.SetCurrentItem(current)
+	}
+	t.showCard(t.list.GetCurrentItem())
+}
+
+func (t *service) showCard(i int) {
+	c, ok := t.currentCard(i)
+	if !ok {
+		t.detail.SetText("")
+		return
+	}
+	text := fmt.Sprintf("[::b]%s[::-]\n\n%s", tview.Escape(c.Title), tview.Escape(c.Desc))
+	if c.Reversible {
+		text += "\n\n[gray](reversible)[-]"
+	}
+	if c.Suspended {
+		text += "\n\n[gray](suspended)[-]"
+	}
+	t.detail.SetText(text)
+}
+
+func (t *service) currentCard(i int) (getting.Card, bool) {
+	if i < 0 || i >= len(t.cards) {
+		return getting.Card{}, false
+	}
+	return t.cards[i], true
+}
+
+func (t *service) setError(err error) {
+	if err == nil {
+		t.status.SetText(help)
+		return
+	}
+	t.status.SetText("[red]" + tview.Escape(err.Error()) + "[-]")
+}
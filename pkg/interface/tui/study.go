@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/core/studying"
+	"github.com/rivo/tview"
+)
+
+var errStudyUnavailable error = errors.New("studying is not available")
+
+const studyHelp = "[::b]space[::-] reveal  [::b]1-4[::-] again/hard/good/easy  [::b]esc[::-] back"
+
+// studyScreen shows a session's cards one at a time, hiding each card's
+// description until it is revealed.
+type studyScreen struct {
+	t        *service
+	session  *studying.Session
+	view     *tview.TextView
+	revealed bool
+	finished bool
+}
+
+func (t *service) showStudy() {
+	if t.s == nil {
+		t.setError(errStudyUnavailable)
+		return
+	}
+	session, err := t.s.Study(t.group, getting.Query{})
+	if err != nil {
+		t.setError(err)
+		return
+	}
+
+	s := &studyScreen{t: t, session: session}
+	s.view = tview.NewTextView().SetDynamicColors(true).SetWrap(true).SetWordWrap(true)
+	s.view.SetBorder(true).SetTitle(" Study " + t.group + " ")
+	s.view.SetInputCapture(s.handleKey)
+
+	frame := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(s.view, 0, 1, true).
+		AddItem(tview.NewTextView().SetDynamicColors(true).SetText(studyHelp), 1, 0, false)
+	t.pages.AddPage(studyPage, frame, true, true)
+	t.app.SetFocus(s.view)
+	s.render()
+}
+
+func (s *studyScreen) close() {
+	s.t.pages.RemovePage(studyPage)
+	s.t.refresh()
+	s.t.app.SetFocus(s.t.tree)
+}
+
+func (s *studyScreen) handleKey(ev *tcell.EventKey) *tcell.EventKey {
+	if ev.Key() == tcell.KeyEscape || ev.Rune() == 'q' {
+		s.close()
+		return nil
+	}
+	if s.finished {
+		return nil
+	}
+
+	switch r := ev.Rune(); {
+	case !s.revealed && (r == ' ' || ev.Key() == tcell.KeyEnter):
+		s.revealed = true
+	case s.revealed && r >= '1' && r <= '4':
+		if _, err := s.session.Grade(reviewing.Grade(r - '1')); err != nil {
+			s.t.setError(err)
+		}
+		s.revealed = false
+	default:
+		return ev
+	}
+	s.render()
+	return nil
+}
+
+func (s *studyScreen) render() {
+	item, ok := s.session.Next()
+	if !ok {
+		s.finished = true
+		s.view.SetText(summaryText(s.session.Summary()))
+		return
+	}
+
+	text := fmt.Sprintf("[%d left] (%s)\n\n[::b]%s[::-]\n\n", s.session.Remaining(), item.Face, tview.Escape(item.Prompt()))
+	if s.revealed {
+		text += tview.Escape(item.Answer())
+	} else {
+		text += "[gray](press space to reveal)[-]"
+	}
+	s.view.SetText(text)
+}
+
+func summaryText(sum studying.Summary) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Reviewed %d card(s) in %s\n\n", sum.Reviewed, sum.Duration.Round(time.Second))
+	for g, n := range sum.Grades {
+		fmt.Fprintf(&b, "\t%-5s %d\n", reviewing.Grade(g), n)
+	}
+	return b.String()
+}
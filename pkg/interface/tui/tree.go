@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/storage"
+	"github.com/rivo/tview"
+)
+
+// newGroupTree builds a tree of every group that holds cards, directly or
+// through its subgroups. Each node references its group's full path.
+func newGroupTree(cards []getting.Card) *tview.TreeNode {
+	root := tview.NewTreeNode("flash").SetReference("")
+	for _, c := range cards {
+		items := strings.Split(c.Title, ".")
+		node := root
+		for i := range items[:len(items)-1] {
+			node = childGroup(node, strings.Join(items[:i+1], "."))
+		}
+	}
+	return root
+}
+
+func childGroup(n *tview.TreeNode, g string) *tview.TreeNode {
+	for _, c := range n.GetChildren() {
+		if c.GetReference().(string) == g {
+			return c
+		}
+	}
+	_, name := storage.SplitPath(g)
+	c := tview.NewTreeNode(name).SetReference(g)
+	n.AddChild(c)
+	return c
+}
+
+func findGroup(n *tview.TreeNode, g string) *tview.TreeNode {
+	if n.GetReference().(string) == g {
+		return n
+	}
+	for _, c := range n.GetChildren() {
+		if f := findGroup(c, g); f != nil {
+			return f
+		}
+	}
+	return nil
+}
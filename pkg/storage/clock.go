@@ -15,3 +15,22 @@ func NewClock() *clock {
 func (c *clock) Now() time.Time {
 	return time.Now()
 }
+
+// fakeClock is a Clock that only moves when told to, for running the
+// schedulers through time without waiting for it.
+type fakeClock struct {
+	now time.Time
+}
+
+func NewFakeClock(t time.Time) *fakeClock {
+	return &fakeClock{t}
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// AddDate moves the clock forward, or back, by the number of days.
+func (c *fakeClock) AddDate(days int) {
+	c.now = c.now.AddDate(0, 0, days)
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// idBytes is the number of random bytes in an ID, enough for collisions to
+// never happen in practice.
+const idBytes = 8
+
+// IDGenerator generates the IDs that cards are known by, whatever their
+// title.
+type IDGenerator interface {
+	NewID() string
+}
+
+type idGenerator struct{}
+
+func NewIDGenerator() *idGenerator {
+	return &idGenerator{}
+}
+
+// NewID returns a random ID in hex.
+func (g *idGenerator) NewID() string {
+	b := make([]byte, idBytes)
+	if _, err := rand.Read(b); err != nil {
+		panic("unable to generate an id: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
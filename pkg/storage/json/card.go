@@ -3,8 +3,43 @@ package json
 import "time"
 
 type Card struct {
-	Title   string
+	ID         string
+	Title      string
+	Desc       string
+	Answers    []string
+	Reversible bool
+	Cloze      bool
+	Created    time.Time
+	Updated    time.Time
+	Schedules  map[string]Schedule
+	Suspended  bool
+	BuriedTill time.Time
+	Leech      bool
+	Tags       []string
+	// Note cards name their note type and hold its fields' values.
+	NoteType string
+	Fields   map[string]string
+	Media    []Media
+	// Revisions hold the content the card had before each update.
+	Revisions []Revision
+}
+
+// Revision is the content a card had from the time until its next update.
+type Revision struct {
 	Desc    string
-	Created time.Time
-	Updated time.Time
+	Answers []string
+	Time    time.Time
+}
+
+type Schedule struct {
+	Due         time.Time
+	Interval    int
+	EaseFactor  float64
+	Repetitions int
+	Stability   float64
+	Difficulty  float64
+	Box         int
+	Reviewed    time.Time
+	Lapses      int
+	Relearning  bool
 }
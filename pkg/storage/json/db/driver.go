@@ -2,8 +2,10 @@ package db
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	scribble "github.com/nanobox-io/golang-scribble"
 )
@@ -13,7 +15,21 @@ type Driver interface {
 	Read(string, string, any) error
 	ReadAll(string) ([]string, error)
 	ReadAllRecursive(string) ([]string, error)
+	ReadAllRecords(string) ([]Record, error)
 	Delete(string, string) error
+	// Files are stored as is, rather than as JSON records.
+	WriteFile(string, string, []byte) error
+	ReadFile(string, string) ([]byte, error)
+	ListFiles(string) ([]string, error)
+	DeleteFile(string, string) error
+}
+
+// Record is a record as read from a collection, with the collection and
+// resource it is stored under.
+type Record struct {
+	Collection string
+	Resource   string
+	Data       string
 }
 
 type driver struct {
@@ -54,7 +70,7 @@ func (d *driver) ReadAll(collection string) ([]string, error) {
 
 	f, err := os.Open(dir)
 	if err != nil {
-		return records, errors.New("unable to read collection directory")
+		return records, fmt.Errorf("unable to read collection directory: %w", err)
 	}
 	defer f.Close()
 
@@ -83,7 +99,18 @@ func (d *driver) ReadAll(collection string) ([]string, error) {
 }
 
 func (d *driver) ReadAllRecursive(collection string) ([]string, error) {
-	var records []string
+	records, err := d.ReadAllRecords(collection)
+	var data []string
+	for _, r := range records {
+		data = append(data, r.Data)
+	}
+	return data, err
+}
+
+// ReadAllRecords reads every record under the collection, like
+// ReadAllRecursive, along with where each one is stored.
+func (d *driver) ReadAllRecords(collection string) ([]Record, error) {
+	var records []Record
 
 	if collection == "" {
 		return nil, errors.New("collection is missing")
@@ -96,7 +123,7 @@ func (d *driver) ReadAllRecursive(collection string) ([]string, error) {
 
 	f, err := os.Open(dir)
 	if err != nil {
-		return records, errors.New("unable to read collection directory")
+		return records, fmt.Errorf("unable to read collection directory: %w", err)
 	}
 	defer f.Close()
 
@@ -107,7 +134,7 @@ func (d *driver) ReadAllRecursive(collection string) ([]string, error) {
 
 	for _, item := range items {
 		if item.IsDir() {
-			r, err := d.ReadAll(filepath.Join(collection, item.Name()))
+			r, err := d.ReadAllRecords(collection + "/" + item.Name())
 			if err != nil {
 				return records, err
 			}
@@ -123,7 +150,11 @@ func (d *driver) ReadAllRecursive(collection string) ([]string, error) {
 			return records, err
 		}
 
-		records = append(records, string(b))
+		records = append(records, Record{
+			Collection: collection,
+			Resource:   strings.TrimSuffix(item.Name(), ".json"),
+			Data:       string(b),
+		})
 	}
 
 	return records, nil
@@ -132,3 +163,55 @@ func (d *driver) ReadAllRecursive(collection string) ([]string, error) {
 func (d *driver) Delete(collection, resource string) error {
 	return d.db.Delete(collection, resource)
 }
+
+// WriteFile writes the data to a file in the collection's directory, through
+// a temporary file so it is never left half written.
+func (d *driver) WriteFile(collection, name string, data []byte) error {
+	dir := filepath.Join(d.dir, collection)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, name+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(dir, name))
+}
+
+func (d *driver) ReadFile(collection, name string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(d.dir, collection, name))
+}
+
+// ListFiles returns the names of the files in the collection's directory,
+// or none if it does not exist.
+func (d *driver) ListFiles(collection string) ([]string, error) {
+	items, err := os.ReadDir(filepath.Join(d.dir, collection))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, item := range items {
+		if !item.IsDir() {
+			names = append(names, item.Name())
+		}
+	}
+	return names, nil
+}
+
+func (d *driver) DeleteFile(collection, name string) error {
+	return os.Remove(filepath.Join(d.dir, collection, name))
+}
@@ -0,0 +1,24 @@
+package json
+
+import "time"
+
+type Group struct {
+	Name           string
+	Scheduler      string
+	Weights        []float64
+	Retention      float64
+	Boxes          []int
+	NewPerDay      int
+	ReviewsPerDay  int
+	LeechThreshold int
+	LeechAction    string
+	Target         time.Time
+	TargetReviews  int
+	Studied        DailyCount
+}
+
+type DailyCount struct {
+	Day     time.Time
+	New     int
+	Reviews int
+}
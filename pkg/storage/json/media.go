@@ -0,0 +1,8 @@
+package json
+
+// Media is a file attached to a card, under its original name and the name
+// it is stored under in the media directory.
+type Media struct {
+	Name string
+	File string
+}
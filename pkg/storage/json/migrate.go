@@ -0,0 +1,76 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+)
+
+const (
+	metaCollection = "meta"
+	schemaResource = "schema"
+)
+
+// Schema records which migrations a store has had.
+type Schema struct {
+	Version int
+}
+
+// migrations bring a store up to date, in order. A store at version n has
+// had the first n.
+var migrations = []func(*repository) error{
+	(*repository).addCardIDs,
+}
+
+// migrate runs the migrations the store has not had yet, recording each one
+// as it completes.
+func (r *repository) migrate() error {
+	schema := Schema{}
+	if err := r.db.Read(metaCollection, schemaResource, &schema); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	for schema.Version < len(migrations) {
+		if err := migrations[schema.Version](r); err != nil {
+			return err
+		}
+		schema.Version++
+		if err := r.db.Write(metaCollection, schemaResource, schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addCardIDs gives an ID to every card added before cards had one, and its
+// full path as its Title in place of the title alone it was stored with,
+// writing it back where it is stored.
+func (r *repository) addCardIDs() error {
+	records, err := r.db.ReadAllRecords(cardCollection)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	for _, rec := range records {
+		var card Card
+		if err := json.Unmarshal([]byte(rec.Data), &card); err != nil {
+			return err
+		}
+		p := recordCardPath(rec)
+		if card.ID != "" && card.Title == p {
+			continue
+		}
+
+		if card.ID == "" {
+			card.ID = r.ids.NewID()
+		}
+		card.Title = p
+		if err := r.db.Write(rec.Collection, rec.Resource, card); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"strings"
+
+	"github.com/jmcveigh55/flash/pkg/storage"
+)
+
+// The move in progress is journalled as a single resource, which is written
+// in one rename, before any card is touched.
+const moveResource = "move"
+
+// Move is a journalled move. Cards, Groups and the days of Reviews of the
+// moved cards are already at their new paths, so finishing a move only
+// writes them and removes the source.
+type Move struct {
+	Src     string
+	Group   bool
+	Cards   []Card
+	Groups  []Group
+	Reviews map[string][]Review
+}
+
+// MoveCard gives the card a new path, keeping everything else about it.
+func (r *repository) MoveCard(src, dst string) error {
+	g, title := storage.SplitPath(src)
+	card := Card{}
+	if err := r.db.Read(joinCollectionPaths(cardCollection, g), title, &card); err != nil {
+		return ErrCardNotFound
+	}
+	g, title = storage.SplitPath(dst)
+	if ok := r.checkCardExists(joinCollectionPaths(cardCollection, g), title); ok {
+		return ErrCardFound
+	}
+
+	card.Title = dst
+	m := Move{Src: src, Cards: []Card{card}}
+	if err := r.moveReviews(&m, dst); err != nil {
+		return err
+	}
+	return r.move(m)
+}
+
+// MoveGroup moves every card and group setting under the source group to
+// the destination.
+func (r *repository) MoveGroup(src, dst string) error {
+	items, err := r.db.ReadAllRecursive(joinCollectionPaths(cardCollection, src))
+	if err != nil || len(items) == 0 {
+		return ErrGroupNotFound
+	}
+	if found, err := r.db.ReadAllRecursive(joinCollectionPaths(cardCollection, dst)); err == nil && len(found) > 0 {
+		return ErrGroupFound
+	}
+
+	m := Move{Src: src, Group: true}
+	for _, item := range items {
+		var card Card
+		if err := json.Unmarshal([]byte(item), &card); err != nil {
+			return err
+		}
+		card.Title = dst + strings.TrimPrefix(card.Title, src)
+		m.Cards = append(m.Cards, card)
+	}
+
+	groups, err := r.db.ReadAllRecursive(joinCollectionPaths(groupCollection, src))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	for _, item := range groups {
+		var group Group
+		if err := json.Unmarshal([]byte(item), &group); err != nil {
+			return err
+		}
+		group.Name = dst + strings.TrimPrefix(group.Name, src)
+		m.Groups = append(m.Groups, group)
+	}
+
+	if err := r.moveReviews(&m, dst); err != nil {
+		return err
+	}
+	return r.move(m)
+}
+
+// moveReviews adds the days of the review log with reviews of the moved
+// cards to the move, with the reviews under the cards' new paths, so the
+// cards keep their history.
+func (r *repository) moveReviews(m *Move, dst string) error {
+	days, err := r.db.ReadAllRecords(reviewCollection)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	for _, day := range days {
+		var reviews []Review
+		if err := json.Unmarshal([]byte(day.Data), &reviews); err != nil {
+			return err
+		}
+		moved := false
+		for i := range reviews {
+			var ok bool
+			reviews[i].Card, ok = storage.MovedPath(reviews[i].Card, m.Src, dst, m.Group)
+			moved = moved || ok
+		}
+		if moved {
+			if m.Reviews == nil {
+				m.Reviews = map[string][]Review{}
+			}
+			m.Reviews[day.Resource] = reviews
+		}
+	}
+	return nil
+}
+
+// move journals the move and then carries it out. A move interrupted after
+// it is journalled is finished the next time the store is opened, so the
+// source and destination are never left half moved.
+func (r *repository) move(m Move) error {
+	if err := r.db.Write(metaCollection, moveResource, m); err != nil {
+		return err
+	}
+	return r.finishMove(m)
+}
+
+// finishMove writes the moved cards and groups and removes the source. It
+// can be run again on a move that was partly carried out.
+func (r *repository) finishMove(m Move) error {
+	for _, card := range m.Cards {
+		g, title := storage.SplitPath(card.Title)
+		if err := r.db.Write(joinCollectionPaths(cardCollection, g), title, card); err != nil {
+			return err
+		}
+	}
+	for _, group := range m.Groups {
+		if err := r.setGroup(group); err != nil {
+			return err
+		}
+	}
+	for day, reviews := range m.Reviews {
+		if err := r.db.Write(reviewCollection, day, reviews); err != nil {
+			return err
+		}
+	}
+
+	if m.Group {
+		if err := r.deleteCollection(joinCollectionPaths(cardCollection, m.Src)); err != nil {
+			return err
+		}
+		if err := r.deleteCollection(joinCollectionPaths(groupCollection, m.Src)); err != nil {
+			return err
+		}
+	} else {
+		g, title := storage.SplitPath(m.Src)
+		subCollection := joinCollectionPaths(cardCollection, g)
+		if ok := r.checkCardExists(subCollection, title); ok {
+			if err := r.db.Delete(subCollection, title); err != nil {
+				return err
+			}
+		}
+	}
+
+	return r.db.Delete(metaCollection, moveResource)
+}
+
+// recoverMove finishes a move that was interrupted, if there is one.
+func (r *repository) recoverMove() error {
+	m := Move{}
+	if err := r.db.Read(metaCollection, moveResource, &m); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return r.finishMove(m)
+}
+
+// deleteCollection deletes the collection and everything under it, if it
+// exists.
+func (r *repository) deleteCollection(coll string) error {
+	if _, err := r.db.ReadAllRecursive(coll); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return r.db.Delete(coll, "")
+}
@@ -3,25 +3,56 @@ package json
 import (
 	"encoding/json"
 	"errors"
+	"io/fs"
 	"os/user"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/jmcveigh55/flash/pkg/core/adding"
+	"github.com/jmcveigh55/flash/pkg/core/attaching"
+	"github.com/jmcveigh55/flash/pkg/core/burying"
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
 	"github.com/jmcveigh55/flash/pkg/core/deleting"
 	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/logging"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/core/studying"
+	"github.com/jmcveigh55/flash/pkg/core/suspending"
+	"github.com/jmcveigh55/flash/pkg/core/tagging"
 	"github.com/jmcveigh55/flash/pkg/core/updating"
 	"github.com/jmcveigh55/flash/pkg/storage"
 	"github.com/jmcveigh55/flash/pkg/storage/json/db"
 )
 
-const cardCollection = "card"
+const (
+	cardCollection  = "card"
+	groupCollection = "group"
+	groupResource   = "settings"
+	// Note types are stored in a resource per type.
+	noteTypeCollection = "notetype"
+	// Reviews are logged in a resource per day.
+	reviewCollection = "review"
+	reviewResource   = "2006-01-02"
+	// Attached files are stored as is, named by the hash of their content.
+	mediaCollection = "media"
+)
 
 var (
 	dataPath = "/tmp/.flash"
 
 	ErrCardFound     = errors.New("card already exists")
 	ErrCardNotFound  = errors.New("card not found")
-	ErrGroupNotFound = errors.New("group not found")
+	ErrGroupNotFound = getting.ErrGroupNotFound
+	ErrGroupFound    = errors.New("group already exists")
+	ErrClozeNotFound = errors.New("cloze card has no deletions")
+	ErrFaceNotFound  = errors.New("card has no such face")
+	ErrNoteCard      = errors.New("note cards are made from their note's fields and cannot be updated")
+
+	ErrNoteTypeFound    = errors.New("note type already exists")
+	ErrNoteTypeNotFound = errors.New("note type not found")
+
+	ErrMediaNotFound = errors.New("media not found")
 )
 
 func joinCollectionPaths(c1, c2 string) string {
@@ -31,19 +62,35 @@ func joinCollectionPaths(c1, c2 string) string {
 	return c1 + "/" + strings.Replace(c2, ".", "/", -1)
 }
 
+// recordCardPath returns the path of the card stored in the record, from
+// the collection it is stored under rather than its Title.
+func recordCardPath(rec db.Record) string {
+	g := strings.TrimPrefix(strings.TrimPrefix(rec.Collection, cardCollection), "/")
+	return storage.JoinPath(strings.Replace(g, "/", ".", -1), rec.Resource)
+}
+
 type repository struct {
 	db    db.Driver
 	clock storage.Clock
+	ids   storage.IDGenerator
 }
 
+// New opens the store in the user's home directory, migrating it to the
+// current schema and finishing any interrupted move first if needed.
 func New() (*repository, error) {
 	usr, err := user.Current()
 	if err == nil {
 		dataPath = usr.HomeDir + "/.flash"
 	}
 	db, err := db.New(dataPath)
-	c := storage.NewClock()
-	return &repository{db, c}, err
+	r := &repository{db, storage.NewClock(), storage.NewIDGenerator()}
+	if err != nil {
+		return r, err
+	}
+	if err := r.migrate(); err != nil {
+		return r, err
+	}
+	return r, r.recoverMove()
 }
 
 func (r *repository) checkCardExists(coll, title string) bool {
@@ -68,17 +115,106 @@ func (r *repository) AddCard(g string, c adding.Card) error {
 
 	t := r.clock.Now()
 	card := Card{
-		Title:   c.Title,
-		Desc:    c.Desc,
-		Created: t,
-		Updated: t,
+		ID:         r.ids.NewID(),
+		Title:      storage.JoinPath(g, c.Title),
+		Desc:       c.Desc,
+		Answers:    c.Answers,
+		Reversible: c.Reversible,
+		Cloze:      c.Cloze,
+		Tags:       storage.AddTags(nil, c.Tags),
+		Created:    t,
+		Updated:    t,
 	}
 
-	err := r.db.Write(subCollection, card.Title, card)
+	err := r.db.Write(subCollection, c.Title, card)
 	return err
 }
 
+func (r *repository) AddNoteType(t adding.NoteType) error {
+	if err := r.db.Read(noteTypeCollection, t.Name, &NoteType{}); err == nil {
+		return ErrNoteTypeFound
+	}
+	return r.db.Write(noteTypeCollection, t.Name, toNoteType(t))
+}
+
+func (r *repository) GetNoteType(name string) (adding.NoteType, error) {
+	t := NoteType{}
+	if err := r.db.Read(noteTypeCollection, name, &t); err != nil {
+		return adding.NoteType{}, ErrNoteTypeNotFound
+	}
+	return toAddingNoteType(t), nil
+}
+
+func (r *repository) AddNote(g string, n adding.Note) error {
+	subCollection := joinCollectionPaths(cardCollection, g)
+	if ok := r.checkCardExists(subCollection, n.Title); ok {
+		return ErrCardFound
+	}
+
+	t := r.clock.Now()
+	card := Card{
+		ID:       r.ids.NewID(),
+		Title:    storage.JoinPath(g, n.Title),
+		Tags:     storage.AddTags(nil, n.Tags),
+		NoteType: n.Type,
+		Fields:   n.Fields,
+		Created:  t,
+		Updated:  t,
+	}
+	return r.db.Write(subCollection, n.Title, card)
+}
+
+// addTemplates gives the note cards the templates of their type, reading
+// each type once.
+func (r *repository) addTemplates(cards []getting.Card) error {
+	types := map[string]NoteType{}
+	for _, c := range cards {
+		if c.Note == nil {
+			continue
+		}
+		t, ok := types[c.Note.Type]
+		if !ok {
+			if err := r.db.Read(noteTypeCollection, c.Note.Type, &t); err != nil {
+				return ErrNoteTypeNotFound
+			}
+			types[c.Note.Type] = t
+		}
+		c.Note.Templates = toGettingTemplates(t.Templates)
+	}
+	return nil
+}
+
+// findCard returns the group and title of the card with the ID, or the
+// given group and title when the ID is empty.
+func (r *repository) findCard(g, id, title string) (string, string, error) {
+	if id == "" {
+		return g, title, nil
+	}
+
+	records, err := r.db.ReadAllRecords(cardCollection)
+	if err != nil {
+		return "", "", ErrCardNotFound
+	}
+	for _, rec := range records {
+		var c Card
+		if err := json.Unmarshal([]byte(rec.Data), &c); err != nil {
+			return "", "", err
+		}
+		if c.ID == id {
+			g, title := storage.SplitPath(recordCardPath(rec))
+			return g, title, nil
+		}
+	}
+	return "", "", ErrCardNotFound
+}
+
 func (r *repository) DeleteCard(g string, c deleting.Card) error {
+	g, title, err := r.findCard(g, c.ID, c.Title)
+	if err != nil {
+		return err
+	}
+	c.Title = title
+
 	subCollection := joinCollectionPaths(cardCollection, g)
 	if ok := r.checkGroupExists(subCollection); !ok {
 		return ErrGroupNotFound
@@ -102,14 +238,14 @@ func (r *repository) GetCards(g string) ([]getting.Card, error) {
 		return cards, err
 	}
 
-	var c getting.Card
 	for _, item := range items {
+		var c Card
 		if err := json.Unmarshal([]byte(item), &c); err != nil {
 			return cards, err
 		}
-		cards = append(cards, getting.Card{Title: c.Title, Desc: c.Desc})
+		cards = append(cards, toGettingCard(c))
 	}
-	return cards, nil
+	return cards, r.addTemplates(cards)
 }
 
 func (r *repository) GetAllCards(g string) ([]getting.Card, error) {
@@ -124,17 +260,22 @@ func (r *repository) GetAllCards(g string) ([]getting.Card, error) {
 		return cards, err
 	}
 
-	var c getting.Card
 	for _, item := range items {
+		var c Card
 		if err := json.Unmarshal([]byte(item), &c); err != nil {
 			return cards, err
 		}
-		cards = append(cards, getting.Card{Title: c.Title, Desc: c.Desc})
+		cards = append(cards, toGettingCard(c))
 	}
-	return cards, nil
+	return cards, r.addTemplates(cards)
 }
 
 func (r *repository) UpdateCard(g string, c updating.Card) error {
+	g, title, err := r.findCard(g, c.ID, c.Title)
+	if err != nil {
+		return err
+	}
+	c.Title = title
 
 	subCollection := joinCollectionPaths(cardCollection, g)
 	if ok := r.checkGroupExists(subCollection); !ok {
@@ -150,12 +291,478 @@ func (r *repository) UpdateCard(g string, c updating.Card) error {
 		return err
 	}
 
-	u := Card{
-		Title:   c.Title,
-		Desc:    c.Desc,
-		Created: card.Created,
-		Updated: r.clock.Now(),
+	if card.NoteType != "" {
+		return ErrNoteCard
+	}
+	if card.Cloze {
+		if len(c.Clozes) == 0 {
+			return ErrClozeNotFound
+		}
+		keepSchedules(&card, c.Clozes)
+	}
+	if storage.Revised(card.Desc, card.Answers, c.Desc, c.Answers) {
+		card.Revisions = append(card.Revisions, Revision{card.Desc, card.Answers, card.Updated})
+	}
+	card.Desc = c.Desc
+	if c.Answers != nil {
+		card.Answers = c.Answers
+	}
+	if c.Tags != nil {
+		card.Tags = storage.AddTags(nil, c.Tags)
+	}
+	card.Updated = r.clock.Now()
+
+	return r.db.Write(subCollection, c.Title, card)
+}
+
+func (r *repository) GetSchedule(g string, c reviewing.Card) (reviewing.Card, error) {
+	g, title, err := r.findCard(g, "", c.Title)
+	if err != nil {
+		return reviewing.Card{}, err
+	}
+
+	subCollection := joinCollectionPaths(cardCollection, g)
+	if ok := r.checkCardExists(subCollection, title); !ok {
+		return reviewing.Card{}, ErrCardNotFound
+	}
+
+	card := Card{}
+	if err := r.db.Read(subCollection, title, &card); err != nil {
+		return reviewing.Card{}, err
+	}
+	if !toGettingCard(card).HasFace(c.Face) {
+		return reviewing.Card{}, ErrFaceNotFound
+	}
+
+	return toReviewingCard(c, card.Schedules[c.Face]), nil
+}
+
+func (r *repository) UpdateSchedule(g string, c reviewing.Card) error {
+	return r.updateCard(g, "", c.Title, func(card *Card) {
+		setSchedule(card, c)
+	})
+}
+
+func (r *repository) MarkLeech(g string, c reviewing.Card, suspend bool) error {
+	return r.updateCard(g, "", c.Title, func(card *Card) {
+		card.Leech = true
+		if suspend {
+			card.Suspended = true
+		}
+	})
+}
+
+func (r *repository) SetCardSuspended(g string, c suspending.Card, suspended bool) error {
+	return r.updateCard(g, "", c.Title, func(card *Card) {
+		card.Suspended = suspended
+	})
+}
+
+func (r *repository) SetGroupSuspended(g string, suspended bool) error {
+	return r.updateGroupCards(g, func(card *Card) {
+		card.Suspended = suspended
+	})
+}
+
+func (r *repository) SetCardBuried(g string, c burying.Card, t time.Time) error {
+	return r.updateCard(g, "", c.Title, func(card *Card) {
+		card.BuriedTill = t
+	})
+}
+
+func (r *repository) SetGroupBuried(g string, t time.Time) error {
+	return r.updateGroupCards(g, func(card *Card) {
+		card.BuriedTill = t
+	})
+}
+
+func (r *repository) AddCardTags(g string, c tagging.Card, tags []string) error {
+	return r.updateCard(g, c.ID, c.Title, func(card *Card) {
+		card.Tags = storage.AddTags(card.Tags, tags)
+	})
+}
+
+func (r *repository) RemoveCardTags(g string, c tagging.Card, tags []string) error {
+	return r.updateCard(g, c.ID, c.Title, func(card *Card) {
+		card.Tags = storage.RemoveTags(card.Tags, tags)
+	})
+}
+
+// AddMedia stores the data under the file name. As the name is the hash of
+// the data, writing it again leaves the file as it was.
+func (r *repository) AddMedia(file string, data []byte) error {
+	return r.db.WriteFile(mediaCollection, file, data)
+}
+
+func (r *repository) AttachMedia(g string, c attaching.Card, m attaching.Media) error {
+	return r.updateCard(g, c.ID, c.Title, func(card *Card) {
+		for _, media := range card.Media {
+			if media.File == m.File {
+				return
+			}
+		}
+		card.Media = append(card.Media, Media{Name: m.Name, File: m.File})
+	})
+}
+
+// GetMedia returns the file names of the media stored, in order.
+func (r *repository) GetMedia() ([]string, error) {
+	files, err := r.db.ListFiles(mediaCollection)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (r *repository) ReadMedia(file string) ([]byte, error) {
+	data, err := r.db.ReadFile(mediaCollection, file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrMediaNotFound
+	}
+	return data, err
+}
+
+func (r *repository) DeleteMedia(file string) error {
+	err := r.db.DeleteFile(mediaCollection, file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrMediaNotFound
+	}
+	return err
+}
+
+// updateCard reads the card found by findCard, applies f to it and writes it
+// back.
+func (r *repository) updateCard(g, id, title string, f func(*Card)) error {
+	g, title, err := r.findCard(g, id, title)
+	if err != nil {
+		return err
+	}
+
+	subCollection := joinCollectionPaths(cardCollection, g)
+	if ok := r.checkCardExists(subCollection, title); !ok {
+		return ErrCardNotFound
 	}
 
-	return r.db.Write(subCollection, u.Title, u)
+	card := Card{}
+	if err := r.db.Read(subCollection, title, &card); err != nil {
+		return err
+	}
+
+	f(&card)
+
+	return r.db.Write(subCollection, title, card)
+}
+
+// updateGroupCards applies f to every card under the group, writing each
+// one back in place.
+func (r *repository) updateGroupCards(g string, f func(*Card)) error {
+	subCollection := joinCollectionPaths(cardCollection, g)
+	if ok := r.checkGroupExists(subCollection); !ok {
+		return ErrGroupNotFound
+	}
+
+	records, err := r.db.ReadAllRecords(subCollection)
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range records {
+		var card Card
+		if err := json.Unmarshal([]byte(rec.Data), &card); err != nil {
+			return err
+		}
+
+		f(&card)
+
+		if err := r.db.Write(rec.Collection, rec.Resource, card); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) AddReview(rv reviewing.Review) error {
+	day := rv.Time.Format(reviewResource)
+	reviews := []Review{}
+	if err := r.db.Read(reviewCollection, day, &reviews); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	reviews = append(reviews, Review{
+		Card:         rv.Card,
+		Face:         rv.Face,
+		Time:         rv.Time,
+		Grade:        int(rv.Grade),
+		Duration:     rv.Duration,
+		LastInterval: rv.LastInterval,
+		Interval:     rv.Interval,
+		Scheduler:    rv.Scheduler,
+	})
+	return r.db.Write(reviewCollection, day, reviews)
+}
+
+func (r *repository) GetReviews() ([]logging.Review, error) {
+	reviews := []logging.Review{}
+	days, err := r.db.ReadAll(reviewCollection)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return reviews, nil
+		}
+		return reviews, err
+	}
+
+	for _, day := range days {
+		var rvs []Review
+		if err := json.Unmarshal([]byte(day), &rvs); err != nil {
+			return reviews, err
+		}
+		for _, rv := range rvs {
+			reviews = append(reviews, toLoggingReview(rv))
+		}
+	}
+	return reviews, nil
+}
+
+func (r *repository) getGroup(g string) (Group, error) {
+	group := Group{}
+	subCollection := joinCollectionPaths(groupCollection, g)
+	if err := r.db.Read(subCollection, groupResource, &group); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Group{Name: g}, nil
+		}
+		return group, err
+	}
+	return group, nil
+}
+
+func (r *repository) setGroup(group Group) error {
+	subCollection := joinCollectionPaths(groupCollection, group.Name)
+	return r.db.Write(subCollection, groupResource, group)
+}
+
+// GetReviewSettings returns the group's review settings, inheriting any
+// that are unset from its parent groups.
+func (r *repository) GetReviewSettings(g string) (reviewing.Settings, error) {
+	c, err := configuring.Resolve(r.GetConfig, g)
+	if err != nil {
+		return reviewing.Settings{}, err
+	}
+	return reviewing.Settings{
+		Scheduler:      c.Scheduler,
+		Weights:        c.Weights,
+		Retention:      c.Retention,
+		Boxes:          c.Boxes,
+		LeechThreshold: c.LeechThreshold,
+		LeechAction:    c.LeechAction,
+		Target:         c.Target,
+		TargetReviews:  c.TargetReviews,
+	}, nil
+}
+
+func (r *repository) GetDailyCount(g string) (studying.DailyCount, error) {
+	group, err := r.getGroup(g)
+	if err != nil {
+		return studying.DailyCount{}, err
+	}
+	return studying.DailyCount{
+		Day:     group.Studied.Day,
+		New:     group.Studied.New,
+		Reviews: group.Studied.Reviews,
+	}, nil
+}
+
+func (r *repository) SetDailyCount(g string, c studying.DailyCount) error {
+	group, err := r.getGroup(g)
+	if err != nil {
+		return err
+	}
+	group.Studied = DailyCount{
+		Day:     c.Day,
+		New:     c.New,
+		Reviews: c.Reviews,
+	}
+	return r.setGroup(group)
+}
+
+func (r *repository) GetConfig(g string) (configuring.Config, error) {
+	group, err := r.getGroup(g)
+	if err != nil {
+		return configuring.Config{}, err
+	}
+	return configuring.Config{
+		Scheduler:      group.Scheduler,
+		Weights:        group.Weights,
+		Retention:      group.Retention,
+		Boxes:          group.Boxes,
+		NewPerDay:      group.NewPerDay,
+		ReviewsPerDay:  group.ReviewsPerDay,
+		LeechThreshold: group.LeechThreshold,
+		LeechAction:    group.LeechAction,
+		Target:         group.Target,
+		TargetReviews:  group.TargetReviews,
+	}, nil
+}
+
+func (r *repository) SetConfig(g string, c configuring.Config) error {
+	group, err := r.getGroup(g)
+	if err != nil {
+		return err
+	}
+	group.Scheduler = c.Scheduler
+	group.Weights = c.Weights
+	group.Retention = c.Retention
+	group.Boxes = c.Boxes
+	group.NewPerDay = c.NewPerDay
+	group.ReviewsPerDay = c.ReviewsPerDay
+	group.LeechThreshold = c.LeechThreshold
+	group.LeechAction = c.LeechAction
+	group.Target = c.Target
+	group.TargetReviews = c.TargetReviews
+	return r.setGroup(group)
+}
+
+func toGettingCard(c Card) getting.Card {
+	var schedules map[string]getting.Schedule
+	for f, s := range c.Schedules {
+		if schedules == nil {
+			schedules = make(map[string]getting.Schedule)
+		}
+		schedules[f] = getting.Schedule{
+			Due:      s.Due,
+			Interval: s.Interval,
+			Box:      s.Box,
+			Lapses:   s.Lapses,
+		}
+	}
+	return getting.Card{
+		ID:         c.ID,
+		Title:      c.Title,
+		Desc:       c.Desc,
+		Answers:    c.Answers,
+		Reversible: c.Reversible,
+		Cloze:      c.Cloze,
+		Created:    c.Created,
+		Updated:    c.Updated,
+		Schedules:  schedules,
+		Suspended:  c.Suspended,
+		BuriedTill: c.BuriedTill,
+		Leech:      c.Leech,
+		Tags:       c.Tags,
+		Note:       toGettingNote(c),
+		Media:      toGettingMedia(c.Media),
+		Revisions:  toGettingRevisions(c.Revisions),
+	}
+}
+
+func toGettingRevisions(revisions []Revision) []getting.Revision {
+	var rs []getting.Revision
+	for _, r := range revisions {
+		rs = append(rs, getting.Revision{Desc: r.Desc, Answers: r.Answers, Time: r.Time})
+	}
+	return rs
+}
+
+func toGettingMedia(media []Media) []getting.Media {
+	var ms []getting.Media
+	for _, m := range media {
+		ms = append(ms, getting.Media{Name: m.Name, File: m.File})
+	}
+	return ms
+}
+
+// toGettingNote returns the note of a note card, without its type's
+// templates, or nil for other cards.
+func toGettingNote(c Card) *getting.Note {
+	if c.NoteType == "" {
+		return nil
+	}
+	return &getting.Note{Type: c.NoteType, Fields: c.Fields}
+}
+
+func toGettingTemplates(templates []Template) []getting.Template {
+	var ts []getting.Template
+	for _, t := range templates {
+		ts = append(ts, getting.Template{Name: t.Name, Front: t.Front, Back: t.Back})
+	}
+	return ts
+}
+
+func toNoteType(t adding.NoteType) NoteType {
+	var templates []Template
+	for _, tmpl := range t.Templates {
+		templates = append(templates, Template{Name: tmpl.Name, Front: tmpl.Front, Back: tmpl.Back})
+	}
+	return NoteType{Name: t.Name, Fields: t.Fields, Templates: templates}
+}
+
+func toAddingNoteType(t NoteType) adding.NoteType {
+	var templates []adding.Template
+	for _, tmpl := range t.Templates {
+		templates = append(templates, adding.Template{Name: tmpl.Name, Front: tmpl.Front, Back: tmpl.Back})
+	}
+	return adding.NoteType{Name: t.Name, Fields: t.Fields, Templates: templates}
+}
+
+func toReviewingCard(c reviewing.Card, s Schedule) reviewing.Card {
+	return reviewing.Card{
+		Title: c.Title,
+		Face:  c.Face,
+		Due:   s.Due,
+		State: reviewing.State{
+			Interval:    s.Interval,
+			EaseFactor:  s.EaseFactor,
+			Repetitions: s.Repetitions,
+			Stability:   s.Stability,
+			Difficulty:  s.Difficulty,
+			Box:         s.Box,
+			Reviewed:    s.Reviewed,
+			Lapses:      s.Lapses,
+			Relearning:  s.Relearning,
+		},
+	}
+}
+
+func setSchedule(card *Card, c reviewing.Card) {
+	if card.Schedules == nil {
+		card.Schedules = make(map[string]Schedule)
+	}
+	card.Schedules[c.Face] = Schedule{
+		Due:         c.Due,
+		Interval:    c.State.Interval,
+		EaseFactor:  c.State.EaseFactor,
+		Repetitions: c.State.Repetitions,
+		Stability:   c.State.Stability,
+		Difficulty:  c.State.Difficulty,
+		Box:         c.State.Box,
+		Reviewed:    c.State.Reviewed,
+		Lapses:      c.State.Lapses,
+		Relearning:  c.State.Relearning,
+	}
+}
+
+func toLoggingReview(rv Review) logging.Review {
+	return logging.Review{
+		Card:         rv.Card,
+		Face:         rv.Face,
+		Time:         rv.Time,
+		Grade:        reviewing.Grade(rv.Grade),
+		Duration:     rv.Duration,
+		LastInterval: rv.LastInterval,
+		Interval:     rv.Interval,
+		Scheduler:    rv.Scheduler,
+	}
+}
+
+// keepSchedules drops the schedules of the faces that are not listed.
+func keepSchedules(card *Card, faces []string) {
+	keep := map[string]bool{}
+	for _, f := range faces {
+		keep[f] = true
+	}
+	for f := range card.Schedules {
+		if !keep[f] {
+			delete(card.Schedules, f)
+		}
+	}
 }
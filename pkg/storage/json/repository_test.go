@@ -5,14 +5,26 @@ import (
 	"errors"
 	"io/fs"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/jmcveigh55/flash/pkg/core/adding"
+	"github.com/jmcveigh55/flash/pkg/core/attaching"
+	"github.com/jmcveigh55/flash/pkg/core/burying"
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
 	"github.com/jmcveigh55/flash/pkg/core/deleting"
 	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/logging"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/core/studying"
+	"github.com/jmcveigh55/flash/pkg/core/suspending"
+	"github.com/jmcveigh55/flash/pkg/core/tagging"
 	"github.com/jmcveigh55/flash/pkg/core/updating"
+	"github.com/jmcveigh55/flash/pkg/storage"
+	"github.com/jmcveigh55/flash/pkg/storage/json/db"
 )
 
 type clockStub struct{}
@@ -21,8 +33,28 @@ func (c *clockStub) Now() time.Time {
 	return time.Time{}
 }
 
+// idGeneratorStub numbers the IDs it generates from 1.
+type idGeneratorStub struct {
+	n int
+}
+
+func (g *idGeneratorStub) NewID() string {
+	g.n++
+	return strconv.Itoa(g.n)
+}
+
 type dbDriverStub struct {
 	cards []Card
+	// paths hold where each card is stored when it is not at its Title, as
+	// cards stored before Titles were full paths are.
+	paths     []string
+	groups    []Group
+	reviews   map[string][]Review
+	schema    *Schema
+	move      *Move
+	noteTypes []NoteType
+	// files are keyed by their collection and name.
+	files map[string][]byte
 }
 
 func removeBaseCollection(coll string) string {
@@ -30,28 +62,75 @@ func removeBaseCollection(coll string) string {
 	return strings.Join(p[1:], ".")
 }
 
-func getCardPath(g, t string) string {
-	if g == "" {
-		return t
+// cardPath returns where the i-th card is stored.
+func (d *dbDriverStub) cardPath(i int) string {
+	if i < len(d.paths) && d.paths[i] != "" {
+		return d.paths[i]
+	}
+	return d.cards[i].Title
+}
+
+func (d *dbDriverStub) setCardPath(i int, cardPath string) {
+	if d.cards[i].Title == cardPath {
+		if i < len(d.paths) {
+			d.paths[i] = ""
+		}
+		return
+	}
+	for len(d.paths) <= i {
+		d.paths = append(d.paths, "")
+	}
+	d.paths[i] = cardPath
+}
+
+func (d *dbDriverStub) deleteCard(i int) {
+	d.cards = append(d.cards[:i], d.cards[i+1:]...)
+	if i < len(d.paths) {
+		d.paths = append(d.paths[:i], d.paths[i+1:]...)
 	}
-	return g + "." + t
 }
 
 func (d *dbDriverStub) Write(collection string, resource string, v any) error {
 	switch val := v.(type) {
 	case Card:
 		g := removeBaseCollection(collection)
-		cardPath := getCardPath(g, val.Title)
+		cardPath := storage.JoinPath(g, resource)
 		for i := range d.cards {
-			if d.cards[i].Title == cardPath {
-				d.cards[i].Desc = val.Desc
+			if d.cardPath(i) == cardPath {
+				d.cards[i] = val
+				d.setCardPath(i, cardPath)
+				return nil
+			}
+		}
+		d.cards = append(d.cards, val)
+		d.setCardPath(len(d.cards)-1, cardPath)
+		return nil
+	case Group:
+		for i := range d.groups {
+			if d.groups[i].Name == val.Name {
+				d.groups[i] = val
 				return nil
 			}
 		}
-		d.cards = append(d.cards, Card{Title: cardPath, Desc: val.Desc})
+		d.groups = append(d.groups, val)
+		return nil
+	case []Review:
+		if d.reviews == nil {
+			d.reviews = map[string][]Review{}
+		}
+		d.reviews[resource] = val
+		return nil
+	case Schema:
+		d.schema = &val
+		return nil
+	case Move:
+		d.move = &val
+		return nil
+	case NoteType:
+		d.noteTypes = append(d.noteTypes, val)
 		return nil
 	default:
-		return errors.New("a Card was not passed to dbDriverStub.Write")
+		return errors.New("a Card, Group, []Review, Schema, Move or NoteType was not passed to dbDriverStub.Write")
 	}
 }
 
@@ -59,24 +138,63 @@ func (d *dbDriverStub) Read(collection, resource string, v any) error {
 	switch val := v.(type) {
 	case *Card:
 		g := removeBaseCollection(collection)
-		cardPath := getCardPath(g, resource)
-		for _, c := range d.cards {
-			if c.Title == cardPath {
+		cardPath := storage.JoinPath(g, resource)
+		for i, c := range d.cards {
+			if d.cardPath(i) == cardPath {
 				*val = c
 				return nil
 			}
 		}
 		return &fs.PathError{}
+	case *Group:
+		g := removeBaseCollection(collection)
+		for _, group := range d.groups {
+			if group.Name == g {
+				*val = group
+				return nil
+			}
+		}
+		return &fs.PathError{Err: fs.ErrNotExist}
+	case *[]Review:
+		reviews, ok := d.reviews[resource]
+		if !ok {
+			return &fs.PathError{Err: fs.ErrNotExist}
+		}
+		*val = reviews
+		return nil
+	case *Schema:
+		if d.schema == nil {
+			return &fs.PathError{Err: fs.ErrNotExist}
+		}
+		*val = *d.schema
+		return nil
+	case *Move:
+		if d.move == nil {
+			return &fs.PathError{Err: fs.ErrNotExist}
+		}
+		*val = *d.move
+		return nil
+	case *NoteType:
+		for _, t := range d.noteTypes {
+			if t.Name == resource {
+				*val = t
+				return nil
+			}
+		}
+		return &fs.PathError{Err: fs.ErrNotExist}
 	default:
-		return errors.New("a *Card was not passed to dbDriverStub.Read")
+		return errors.New("a *Card, *Group, *[]Review, *Schema, *Move or *NoteType was not passed to dbDriverStub.Read")
 	}
 }
 
 func (d *dbDriverStub) ReadAll(collection string) ([]string, error) {
 	var resources []string
+	if collection == reviewCollection {
+		return d.readAllReviews()
+	}
 	g := removeBaseCollection(collection)
-	for _, c := range d.cards {
-		items := strings.Split(c.Title, ".")
+	for i, c := range d.cards {
+		items := strings.Split(d.cardPath(i), ".")
 		p := strings.Join(items[:len(items)-1], ".") // Strip card from title
 		if p == g {
 			b, err := json.Marshal(c)
@@ -93,11 +211,35 @@ func (d *dbDriverStub) ReadAll(collection string) ([]string, error) {
 	return resources, nil
 }
 
+func (d *dbDriverStub) readAllReviews() ([]string, error) {
+	var days []string
+	for day := range d.reviews {
+		days = append(days, day)
+	}
+	if len(days) == 0 {
+		return nil, &fs.PathError{Err: fs.ErrNotExist}
+	}
+	sort.Strings(days)
+
+	var resources []string
+	for _, day := range days {
+		b, err := json.Marshal(d.reviews[day])
+		if err != nil {
+			return resources, err
+		}
+		resources = append(resources, string(b))
+	}
+	return resources, nil
+}
+
 func (d *dbDriverStub) ReadAllRecursive(collection string) ([]string, error) {
 	var resources []string
+	if strings.HasPrefix(collection, groupCollection) {
+		return d.readAllGroups(collection)
+	}
 	g := removeBaseCollection(collection)
-	for _, c := range d.cards {
-		if strings.HasPrefix(c.Title, g) {
+	for i, c := range d.cards {
+		if strings.HasPrefix(d.cardPath(i), g) {
 			b, err := json.Marshal(c)
 			if err != nil {
 				return resources, err
@@ -106,28 +248,153 @@ func (d *dbDriverStub) ReadAllRecursive(collection string) ([]string, error) {
 		}
 	}
 	if len(resources) == 0 {
-		return resources, errors.New("collection not found")
+		return resources, &fs.PathError{Err: fs.ErrNotExist}
 	}
 
 	return resources, nil
 }
 
-func (d *dbDriverStub) Delete(collection string, resource string) error {
+func (d *dbDriverStub) ReadAllRecords(collection string) ([]db.Record, error) {
+	var records []db.Record
+	if collection == reviewCollection {
+		return d.readAllReviewRecords()
+	}
+	g := removeBaseCollection(collection)
 	for i, c := range d.cards {
-		g := removeBaseCollection(collection)
-		cardPath := getCardPath(g, resource)
-		if c.Title == cardPath {
-			d.cards = append(d.cards[:i], d.cards[i+1:]...)
+		cardPath := d.cardPath(i)
+		if g != "" && cardPath != g && !strings.HasPrefix(cardPath, g+".") {
+			continue
+		}
+		b, err := json.Marshal(c)
+		if err != nil {
+			return records, err
+		}
+		group, title := storage.SplitPath(cardPath)
+		coll := cardCollection
+		if group != "" {
+			coll = joinCollectionPaths(cardCollection, group)
+		}
+		records = append(records, db.Record{Collection: coll, Resource: title, Data: string(b)})
+	}
+	if len(records) == 0 {
+		return records, &fs.PathError{Err: fs.ErrNotExist}
+	}
+
+	return records, nil
+}
+
+func (d *dbDriverStub) readAllReviewRecords() ([]db.Record, error) {
+	var records []db.Record
+	for day, reviews := range d.reviews {
+		b, err := json.Marshal(reviews)
+		if err != nil {
+			return records, err
+		}
+		records = append(records, db.Record{Collection: reviewCollection, Resource: day, Data: string(b)})
+	}
+	if len(records) == 0 {
+		return records, &fs.PathError{Err: fs.ErrNotExist}
+	}
+	return records, nil
+}
+
+func (d *dbDriverStub) readAllGroups(collection string) ([]string, error) {
+	var resources []string
+	g := removeBaseCollection(collection)
+	for _, group := range d.groups {
+		if group.Name == g || strings.HasPrefix(group.Name, g+".") {
+			b, err := json.Marshal(group)
+			if err != nil {
+				return resources, err
+			}
+			resources = append(resources, string(b))
+		}
+	}
+	if len(resources) == 0 {
+		return resources, &fs.PathError{Err: fs.ErrNotExist}
+	}
+	return resources, nil
+}
+
+func (d *dbDriverStub) Delete(collection string, resource string) error {
+	if collection == metaCollection {
+		d.move = nil
+		return nil
+	}
+	if resource == "" {
+		return d.deleteCollection(collection)
+	}
+	g := removeBaseCollection(collection)
+	cardPath := storage.JoinPath(g, resource)
+	for i := range d.cards {
+		if d.cardPath(i) == cardPath {
+			d.deleteCard(i)
 			return nil
 		}
 	}
 	return errors.New("Resource not found")
 }
 
+// deleteCollection deletes every card or group under the collection.
+func (d *dbDriverStub) deleteCollection(collection string) error {
+	g := removeBaseCollection(collection)
+	if strings.HasPrefix(collection, groupCollection) {
+		groups := []Group{}
+		for _, group := range d.groups {
+			if group.Name != g && !strings.HasPrefix(group.Name, g+".") {
+				groups = append(groups, group)
+			}
+		}
+		d.groups = groups
+		return nil
+	}
+
+	for i := len(d.cards) - 1; i >= 0; i-- {
+		if strings.HasPrefix(d.cardPath(i), g+".") {
+			d.deleteCard(i)
+		}
+	}
+	return nil
+}
+
+func (d *dbDriverStub) WriteFile(collection, name string, data []byte) error {
+	if d.files == nil {
+		d.files = map[string][]byte{}
+	}
+	d.files[collection+"/"+name] = data
+	return nil
+}
+
+func (d *dbDriverStub) ReadFile(collection, name string) ([]byte, error) {
+	data, ok := d.files[collection+"/"+name]
+	if !ok {
+		return nil, &fs.PathError{Err: fs.ErrNotExist}
+	}
+	return data, nil
+}
+
+func (d *dbDriverStub) ListFiles(collection string) ([]string, error) {
+	var names []string
+	for k := range d.files {
+		if strings.HasPrefix(k, collection+"/") {
+			names = append(names, strings.TrimPrefix(k, collection+"/"))
+		}
+	}
+	return names, nil
+}
+
+func (d *dbDriverStub) DeleteFile(collection, name string) error {
+	if _, ok := d.files[collection+"/"+name]; !ok {
+		return &fs.PathError{Err: fs.ErrNotExist}
+	}
+	delete(d.files, collection+"/"+name)
+	return nil
+}
+
 func newRepositoryWithDbAndClockStubs() (*repository, *dbDriverStub) {
 	d := &dbDriverStub{}
 	c := &clockStub{}
-	r := &repository{db: d, clock: c}
+	r := &repository{db: d, clock: c, ids: &idGeneratorStub{}}
 	return r, d
 }
 
@@ -139,7 +406,7 @@ func newRepositoryWithDbAndClockStubsAndCards() (*repository, *dbDriverStub) {
 		{Title: "Group.Subject1", Desc: "Value1"},
 		{Title: "Group.Subject2", Desc: "Value2"},
 		{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-		{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+		{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 	}
 	return r, db
 }
@@ -156,28 +423,49 @@ func TestAddCardSingle(t *testing.T) {
 			name:    "Normal",
 			group:   "Group",
 			card:    adding.Card{Title: "Subject1", Desc: "Value1"},
-			want:    []Card{{Title: "Group.Subject1", Desc: "Value1"}},
+			want:    []Card{{ID: "1", Title: "Group.Subject1", Desc: "Value1"}},
+			wantErr: nil,
+		},
+		{
+			name:    "Tags",
+			group:   "Group",
+			card:    adding.Card{Title: "Subject1", Desc: "Value1", Tags: []string{"old", "go", "old"}},
+			want:    []Card{{ID: "1", Title: "Group.Subject1", Desc: "Value1", Tags: []string{"go", "old"}}},
 			wantErr: nil,
 		},
 		{
 			name:    "Empty Title",
 			group:   "Group",
 			card:    adding.Card{Title: "", Desc: "Value1"},
-			want:    []Card{{Title: "Group.", Desc: "Value1"}},
+			want:    []Card{{ID: "1", Title: "Group.", Desc: "Value1"}},
 			wantErr: nil,
 		},
 		{
 			name:    "Empty Desc",
 			group:   "Group",
 			card:    adding.Card{Title: "Subject1", Desc: ""},
-			want:    []Card{{Title: "Group.Subject1", Desc: ""}},
+			want:    []Card{{ID: "1", Title: "Group.Subject1", Desc: ""}},
 			wantErr: nil,
 		},
 		{
 			name:    "Empty Group",
 			group:   "",
 			card:    adding.Card{Title: "Subject1", Desc: ""},
-			want:    []Card{{Title: "Subject1", Desc: ""}},
+			want:    []Card{{ID: "1", Title: "Subject1", Desc: ""}},
+			wantErr: nil,
+		},
+		{
+			name:    "Cloze",
+			group:   "Group",
+			card:    adding.Card{Title: "Subject1", Desc: "{{c1::Value1}}", Cloze: true},
+			want:    []Card{{ID: "1", Title: "Group.Subject1", Desc: "{{c1::Value1}}", Cloze: true}},
+			wantErr: nil,
+		},
+		{
+			name:    "Reversible",
+			group:   "Group",
+			card:    adding.Card{Title: "Subject1", Desc: "Value1", Reversible: true},
+			want:    []Card{{ID: "1", Title: "Group.Subject1", Desc: "Value1", Reversible: true}},
 			wantErr: nil,
 		},
 	}
@@ -214,8 +502,8 @@ func TestAddCardMultiple(t *testing.T) {
 				{Title: "Subject2", Desc: "Value2"},
 			},
 			want: []Card{
-				{Title: "Group.Subject1", Desc: "Value1"},
-				{Title: "Group.Subject2", Desc: "Value2"},
+				{ID: "1", Title: "Group.Subject1", Desc: "Value1"},
+				{ID: "2", Title: "Group.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -227,7 +515,7 @@ func TestAddCardMultiple(t *testing.T) {
 				{Title: "Subject1", Desc: "Value2"},
 			},
 			want: []Card{
-				{Title: "Group.Subject1", Desc: "Value1"},
+				{ID: "1", Title: "Group.Subject1", Desc: "Value1"},
 			},
 			wantErr: ErrCardFound,
 		},
@@ -239,8 +527,8 @@ func TestAddCardMultiple(t *testing.T) {
 				{Title: "Subject2", Desc: "Value2"},
 			},
 			want: []Card{
-				{Title: "Subject1", Desc: "Value1"},
-				{Title: "Subject2", Desc: "Value2"},
+				{ID: "1", Title: "Subject1", Desc: "Value1"},
+				{ID: "2", Title: "Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -282,10 +570,37 @@ func TestDeleteCardSingle(t *testing.T) {
 				{Title: "Subject2", Desc: "Value2"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "By ID",
+			group: "Group",
+			card:  deleting.Card{ID: "6"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
 			},
 			wantErr: nil,
 		},
+		{
+			name:  "ID Not Found",
+			group: "Group",
+			card:  deleting.Card{ID: "7", Title: "Subject1"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrCardNotFound,
+		},
 		{
 			name:  "Card not found",
 			group: "Group",
@@ -296,7 +611,7 @@ func TestDeleteCardSingle(t *testing.T) {
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: ErrCardNotFound,
 		},
@@ -309,7 +624,7 @@ func TestDeleteCardSingle(t *testing.T) {
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -348,7 +663,7 @@ func TestDeleteCardMultiple(t *testing.T) {
 				{Title: "Subject1", Desc: "Value1"},
 				{Title: "Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 		},
 		{
@@ -377,7 +692,7 @@ func TestDeleteCardMultiple(t *testing.T) {
 				{Title: "Subject1", Desc: "Value1"},
 				{Title: "Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 		},
 		{
@@ -391,7 +706,7 @@ func TestDeleteCardMultiple(t *testing.T) {
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 		},
 	}
@@ -441,7 +756,7 @@ func TestGetCards(t *testing.T) {
 			group: "Group.SubGroup",
 			want: []getting.Card{
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -485,7 +800,7 @@ func TestGetAllCards(t *testing.T) {
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -496,7 +811,7 @@ func TestGetAllCards(t *testing.T) {
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -505,7 +820,7 @@ func TestGetAllCards(t *testing.T) {
 			group: "Group.SubGroup",
 			want: []getting.Card{
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -548,10 +863,24 @@ func TestUpdateCardSingle(t *testing.T) {
 			want: []Card{
 				{Title: "Subject1", Desc: "Value1"},
 				{Title: "Subject2", Desc: "Value2"},
-				{Title: "Group.Subject1", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value2", Revisions: []Revision{{Desc: "Value1"}}},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Answers",
+			group: "Group",
+			card:  updating.Card{Title: "Subject1", Desc: "Value2", Answers: []string{"Value3"}},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value2", Answers: []string{"Value3"}, Revisions: []Revision{{Desc: "Value1"}}},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -564,8 +893,8 @@ func TestUpdateCardSingle(t *testing.T) {
 				{Title: "Subject2", Desc: "Value2"},
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
-				{Title: "Group.SubGroup.Subject1", Desc: "Value2"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value2", Revisions: []Revision{{Desc: "Value1"}}},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -576,10 +905,24 @@ func TestUpdateCardSingle(t *testing.T) {
 			want: []Card{
 				{Title: "Subject1", Desc: "Value1"},
 				{Title: "Subject2", Desc: "Value2"},
-				{Title: "Group.Subject1", Desc: ""},
+				{Title: "Group.Subject1", Desc: "", Revisions: []Revision{{Desc: "Value1"}}},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "By ID",
+			group: "Group",
+			card:  updating.Card{ID: "6", Desc: "Value3"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value3", Revisions: []Revision{{Desc: "Value2"}}},
 			},
 			wantErr: nil,
 		},
@@ -593,7 +936,7 @@ func TestUpdateCardSingle(t *testing.T) {
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: ErrCardNotFound,
 		},
@@ -632,10 +975,10 @@ func TestUpdateCardMultiple(t *testing.T) {
 			want: []Card{
 				{Title: "Subject1", Desc: "Value1"},
 				{Title: "Subject2", Desc: "Value2"},
-				{Title: "Group.Subject1", Desc: "Value2"},
-				{Title: "Group.Subject2", Desc: "Value3"},
+				{Title: "Group.Subject1", Desc: "Value2", Revisions: []Revision{{Desc: "Value1"}}},
+				{Title: "Group.Subject2", Desc: "Value3", Revisions: []Revision{{Desc: "Value2"}}},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 		},
 		{
@@ -650,8 +993,8 @@ func TestUpdateCardMultiple(t *testing.T) {
 				{Title: "Subject2", Desc: "Value2"},
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
-				{Title: "Group.SubGroup.Subject1", Desc: "Value2"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value3"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value2", Revisions: []Revision{{Desc: "Value1"}}},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value3", Revisions: []Revision{{Desc: "Value2"}}},
 			},
 		},
 		{
@@ -664,10 +1007,10 @@ func TestUpdateCardMultiple(t *testing.T) {
 			want: []Card{
 				{Title: "Subject1", Desc: "Value1"},
 				{Title: "Subject2", Desc: "Value2"},
-				{Title: "Group.Subject1", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value2", Revisions: []Revision{{Desc: "Value1"}}},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 		},
 	}
@@ -686,3 +1029,1346 @@ func TestUpdateCardMultiple(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateCardCloze(t *testing.T) {
+	card := func() Card {
+		return Card{
+			Title: "Group.Subject1", Desc: "{{c1::Value1}} {{c2::Value2}}", Cloze: true,
+			Schedules: map[string]Schedule{"c1": {Interval: 1}, "c2": {Interval: 2}},
+		}
+	}
+	tests := []struct {
+		name    string
+		card    updating.Card
+		want    []Card
+		wantErr error
+	}{
+		{
+			name: "Add Cloze",
+			card: updating.Card{Title: "Subject1", Desc: "{{c1::Value1}} {{c2::Value2}} {{c3::Value3}}", Clozes: []string{"c1", "c2", "c3"}},
+			want: []Card{{
+				Title: "Group.Subject1", Desc: "{{c1::Value1}} {{c2::Value2}} {{c3::Value3}}", Cloze: true,
+				Schedules: map[string]Schedule{"c1": {Interval: 1}, "c2": {Interval: 2}},
+				Revisions: []Revision{{Desc: "{{c1::Value1}} {{c2::Value2}}"}},
+			}},
+			wantErr: nil,
+		},
+		{
+			name: "Remove Cloze",
+			card: updating.Card{Title: "Subject1", Desc: "Value1 {{c2::Value2}}", Clozes: []string{"c2"}},
+			want: []Card{{
+				Title: "Group.Subject1", Desc: "Value1 {{c2::Value2}}", Cloze: true,
+				Schedules: map[string]Schedule{"c2": {Interval: 2}},
+				Revisions: []Revision{{Desc: "{{c1::Value1}} {{c2::Value2}}"}},
+			}},
+			wantErr: nil,
+		},
+		{
+			name:    "No Clozes",
+			card:    updating.Card{Title: "Subject1", Desc: "Value1"},
+			want:    []Card{card()},
+			wantErr: ErrClozeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubs()
+			db.cards = []Card{card()}
+			err := r.UpdateCard("Group", tt.card)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, db.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, db.cards)
+			}
+		})
+	}
+}
+
+func TestUpdateCardNote(t *testing.T) {
+	card := Card{
+		Title: "Group.Subject1", NoteType: "Basic",
+		Fields: map[string]string{"Front": "Subject1", "Back": "Value1"},
+	}
+	r, db := newRepositoryWithDbAndClockStubs()
+	db.cards = []Card{card}
+	err := r.UpdateCard("Group", updating.Card{Title: "Subject1", Desc: "Value2"})
+
+	if err != ErrNoteCard {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrNoteCard, err)
+	}
+	if want := []Card{card}; !reflect.DeepEqual(want, db.cards) {
+		t.Errorf("Incorrect cards. Want %v, got %v", want, db.cards)
+	}
+}
+
+func TestMoveCard(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		dst         string
+		want        []Card
+		wantReviews map[string][]Review
+		wantErr     error
+	}{
+		{
+			name: "Normal",
+			src:  "Group.SubGroup.Subject2",
+			dst:  "Subject3",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Subject3", Desc: "Value2"},
+			},
+			wantReviews: map[string][]Review{
+				"2022-11-01": {{Card: "Subject3"}, {Card: "Group.Subject1"}},
+				"2022-11-02": {{Card: "Group.SubGroup.Subject1"}},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Card Found",
+			src:  "Group.Subject1",
+			dst:  "Group.Subject2",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantReviews: map[string][]Review{
+				"2022-11-01": {{Card: "Group.SubGroup.Subject2"}, {Card: "Group.Subject1"}},
+				"2022-11-02": {{Card: "Group.SubGroup.Subject1"}},
+			},
+			wantErr: ErrCardFound,
+		},
+		{
+			name: "Card Not Found",
+			src:  "Group.NotFound",
+			dst:  "Group.Subject3",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantReviews: map[string][]Review{
+				"2022-11-01": {{Card: "Group.SubGroup.Subject2"}, {Card: "Group.Subject1"}},
+				"2022-11-02": {{Card: "Group.SubGroup.Subject1"}},
+			},
+			wantErr: ErrCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubsAndCards()
+			db.reviews = map[string][]Review{
+				"2022-11-01": {{Card: "Group.SubGroup.Subject2"}, {Card: "Group.Subject1"}},
+				"2022-11-02": {{Card: "Group.SubGroup.Subject1"}},
+			}
+			err := r.MoveCard(tt.src, tt.dst)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if db.move != nil {
+				t.Errorf("Incorrect move. Want none left, got %+v", db.move)
+			}
+			if !reflect.DeepEqual(tt.wantReviews, db.reviews) {
+				t.Errorf("Incorrect reviews. Want %v, got %v", tt.wantReviews, db.reviews)
+			}
+			if !reflect.DeepEqual(tt.want, db.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, db.cards)
+			}
+		})
+	}
+}
+
+func TestMoveGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		dst         string
+		want        []Card
+		wantGroups  []Group
+		wantReviews map[string][]Review
+		wantErr     error
+	}{
+		{
+			name: "Normal",
+			src:  "Group.SubGroup",
+			dst:  "Other",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Other.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Other.Subject2", Desc: "Value2"},
+			},
+			wantGroups: []Group{
+				{Name: "Group", NewPerDay: 10},
+				{Name: "Other", NewPerDay: 5},
+			},
+			wantReviews: map[string][]Review{
+				"2022-11-01": {{Card: "Other.Subject2"}, {Card: "Group.Subject1"}},
+				"2022-11-02": {{Card: "Other.Subject1"}},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Group Found",
+			src:  "Group.SubGroup",
+			dst:  "Group",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantGroups: []Group{
+				{Name: "Group", NewPerDay: 10},
+				{Name: "Group.SubGroup", NewPerDay: 5},
+			},
+			wantReviews: map[string][]Review{
+				"2022-11-01": {{Card: "Group.SubGroup.Subject2"}, {Card: "Group.Subject1"}},
+				"2022-11-02": {{Card: "Group.SubGroup.Subject1"}},
+			},
+			wantErr: ErrGroupFound,
+		},
+		{
+			name: "Group Not Found",
+			src:  "NotFound",
+			dst:  "Other",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantGroups: []Group{
+				{Name: "Group", NewPerDay: 10},
+				{Name: "Group.SubGroup", NewPerDay: 5},
+			},
+			wantReviews: map[string][]Review{
+				"2022-11-01": {{Card: "Group.SubGroup.Subject2"}, {Card: "Group.Subject1"}},
+				"2022-11-02": {{Card: "Group.SubGroup.Subject1"}},
+			},
+			wantErr: ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubsAndCards()
+			db.reviews = map[string][]Review{
+				"2022-11-01": {{Card: "Group.SubGroup.Subject2"}, {Card: "Group.Subject1"}},
+				"2022-11-02": {{Card: "Group.SubGroup.Subject1"}},
+			}
+			db.groups = []Group{
+				{Name: "Group", NewPerDay: 10},
+				{Name: "Group.SubGroup", NewPerDay: 5},
+			}
+			err := r.MoveGroup(tt.src, tt.dst)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if db.move != nil {
+				t.Errorf("Incorrect move. Want none left, got %+v", db.move)
+			}
+			if !reflect.DeepEqual(tt.wantReviews, db.reviews) {
+				t.Errorf("Incorrect reviews. Want %v, got %v", tt.wantReviews, db.reviews)
+			}
+			if !reflect.DeepEqual(tt.want, db.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, db.cards)
+			}
+			if !reflect.DeepEqual(tt.wantGroups, db.groups) {
+				t.Errorf("Incorrect groups. Want %v, got %v", tt.wantGroups, db.groups)
+			}
+		})
+	}
+}
+
+func TestRecoverMove(t *testing.T) {
+	r, db := newRepositoryWithDbAndClockStubsAndCards()
+	// The move was journalled but only one card was written before it was
+	// interrupted.
+	db.cards = append(db.cards, Card{Title: "Other.Subject1", Desc: "Value1"})
+	db.move = &Move{
+		Src:   "Group.SubGroup",
+		Group: true,
+		Cards: []Card{
+			{Title: "Other.Subject1", Desc: "Value1"},
+			{ID: "6", Title: "Other.Subject2", Desc: "Value2"},
+		},
+		Reviews: map[string][]Review{
+			"2022-11-01": {{Card: "Other.Subject2"}},
+		},
+	}
+
+	if err := r.recoverMove(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []Card{
+		{Title: "Subject1", Desc: "Value1"},
+		{Title: "Subject2", Desc: "Value2"},
+		{Title: "Group.Subject1", Desc: "Value1"},
+		{Title: "Group.Subject2", Desc: "Value2"},
+		{Title: "Other.Subject1", Desc: "Value1"},
+		{ID: "6", Title: "Other.Subject2", Desc: "Value2"},
+	}
+	if !reflect.DeepEqual(want, db.cards) {
+		t.Errorf("Incorrect cards. Want %v, got %v", want, db.cards)
+	}
+	wantReviews := map[string][]Review{
+		"2022-11-01": {{Card: "Other.Subject2"}},
+	}
+	if !reflect.DeepEqual(wantReviews, db.reviews) {
+		t.Errorf("Incorrect reviews. Want %v, got %v", wantReviews, db.reviews)
+	}
+	if db.move != nil {
+		t.Errorf("Incorrect move. Want none left, got %+v", db.move)
+	}
+}
+
+func TestNotes(t *testing.T) {
+	vocab := adding.NoteType{
+		Name:   "Vocab",
+		Fields: []string{"Word", "Meaning"},
+		Templates: []adding.Template{
+			{Name: "Recognise", Front: "{{Word}}", Back: "{{Meaning}}"},
+			{Name: "Recall", Front: "{{Meaning}}", Back: "{{Word}}"},
+		},
+	}
+	r, _ := newRepositoryWithDbAndClockStubs()
+
+	if err := r.AddNoteType(vocab); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := r.AddNoteType(vocab); err != ErrNoteTypeFound {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrNoteTypeFound, err)
+	}
+	if got, err := r.GetNoteType("Vocab"); err != nil || !reflect.DeepEqual(vocab, got) {
+		t.Errorf("Incorrect note type. Want %v, got %v (%v)", vocab, got, err)
+	}
+	if _, err := r.GetNoteType("Grammar"); err != ErrNoteTypeNotFound {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrNoteTypeNotFound, err)
+	}
+
+	fields := map[string]string{"Word": "猫", "Meaning": "cat"}
+	if err := r.AddNote("Japanese", adding.Note{Title: "Cat", Type: "Vocab", Fields: fields, Tags: []string{"n5"}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := r.AddNote("Japanese", adding.Note{Title: "Cat", Type: "Vocab"}); err != ErrCardFound {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrCardFound, err)
+	}
+
+	want := []getting.Card{{
+		ID:    "1",
+		Title: "Japanese.Cat",
+		Tags:  []string{"n5"},
+		Note: &getting.Note{
+			Type:   "Vocab",
+			Fields: fields,
+			Templates: []getting.Template{
+				{Name: "Recognise", Front: "{{Word}}", Back: "{{Meaning}}"},
+				{Name: "Recall", Front: "{{Meaning}}", Back: "{{Word}}"},
+			},
+		},
+	}}
+	got, err := r.GetCards("Japanese")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Incorrect cards. Want %+v, got %+v", want, got)
+	}
+	if faces := got[0].Faces(); !reflect.DeepEqual([]string{"Recognise", "Recall"}, faces) {
+		t.Errorf("Incorrect faces. Want %v, got %v", []string{"Recognise", "Recall"}, faces)
+	}
+}
+
+func TestGetSchedule(t *testing.T) {
+	due := time.Date(2022, time.November, 7, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		group   string
+		card    reviewing.Card
+		want    reviewing.Card
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			card:  reviewing.Card{Title: "Subject1", Face: getting.Forward},
+			want: reviewing.Card{
+				Title: "Subject1", Face: getting.Forward, Due: due,
+				State: reviewing.State{Interval: 6, EaseFactor: 2.5, Repetitions: 2, Box: 2},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "New Face",
+			group:   "Group",
+			card:    reviewing.Card{Title: "Subject1", Face: getting.Reverse},
+			want:    reviewing.Card{Title: "Subject1", Face: getting.Reverse},
+			wantErr: nil,
+		},
+		{
+			name:    "New Card",
+			group:   "Group",
+			card:    reviewing.Card{Title: "Subject2", Face: getting.Forward},
+			want:    reviewing.Card{Title: "Subject2", Face: getting.Forward},
+			wantErr: nil,
+		},
+		{
+			name:    "Face Not Found",
+			group:   "Group",
+			card:    reviewing.Card{Title: "Subject2", Face: getting.Reverse},
+			want:    reviewing.Card{},
+			wantErr: ErrFaceNotFound,
+		},
+		{
+			name:    "Card Not Found",
+			group:   "Group",
+			card:    reviewing.Card{Title: "Subject3"},
+			want:    reviewing.Card{},
+			wantErr: ErrCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubsAndCards()
+			db.cards[2].Reversible = true
+			db.cards[2].Schedules = map[string]Schedule{
+				getting.Forward: {Due: due, Interval: 6, EaseFactor: 2.5, Repetitions: 2, Box: 2},
+			}
+			card, err := r.GetSchedule(tt.group, tt.card)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, card) {
+				t.Errorf("Incorrect card. Want %v, got %v", tt.want, card)
+			}
+		})
+	}
+}
+
+func TestUpdateSchedule(t *testing.T) {
+	due := time.Date(2022, time.November, 7, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		group   string
+		card    reviewing.Card
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			card: reviewing.Card{
+				Title: "Subject1", Face: getting.Reverse, Due: due,
+				State: reviewing.State{Interval: 1, Stability: 3.7, Difficulty: 5.2, Repetitions: 1},
+			},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{
+					Title: "Group.Subject1", Desc: "Value1",
+					Schedules: map[string]Schedule{
+						getting.Reverse: {Due: due, Interval: 1, Stability: 3.7, Difficulty: 5.2, Repetitions: 1},
+					},
+				},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Card Not Found",
+			group: "Group",
+			card:  reviewing.Card{Title: "Subject3", Due: due},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubsAndCards()
+			err := r.UpdateSchedule(tt.group, tt.card)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, db.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, db.cards)
+			}
+		})
+	}
+}
+
+func TestGetConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		want    configuring.Config
+		wantErr error
+	}{
+		{
+			name:    "Normal",
+			group:   "Group",
+			want:    configuring.Config{Scheduler: "fsrs", Retention: 0.85},
+			wantErr: nil,
+		},
+		{
+			name:    "Unset",
+			group:   "Group.SubGroup",
+			want:    configuring.Config{},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubs()
+			db.groups = []Group{{Name: "Group", Scheduler: "fsrs", Retention: 0.85}}
+			config, err := r.GetConfig(tt.group)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, config) {
+				t.Errorf("Incorrect config. Want %v, got %v", tt.want, config)
+			}
+		})
+	}
+}
+
+func TestSetConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		config  configuring.Config
+		want    []Group
+		wantErr error
+	}{
+		{
+			name:   "Normal",
+			group:  "Group.SubGroup",
+			config: configuring.Config{Scheduler: "sm2"},
+			want: []Group{
+				{Name: "Group", Scheduler: "fsrs", Retention: 0.85},
+				{Name: "Group.SubGroup", Scheduler: "sm2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Overwrite",
+			group:  "Group",
+			config: configuring.Config{Scheduler: "leitner", Boxes: []int{1, 3, 7}},
+			want: []Group{
+				{Name: "Group", Scheduler: "leitner", Boxes: []int{1, 3, 7}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Limits",
+			group:  "Group",
+			config: configuring.Config{Scheduler: "fsrs", NewPerDay: 20, ReviewsPerDay: 200},
+			want: []Group{
+				{Name: "Group", Scheduler: "fsrs", NewPerDay: 20, ReviewsPerDay: 200},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Leech",
+			group:  "Group",
+			config: configuring.Config{LeechThreshold: 4, LeechAction: "suspend"},
+			want: []Group{
+				{Name: "Group", LeechThreshold: 4, LeechAction: "suspend"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Target",
+			group:  "Group",
+			config: configuring.Config{Target: time.Date(2022, time.December, 1, 0, 0, 0, 0, time.UTC), TargetReviews: 2},
+			want: []Group{
+				{Name: "Group", Target: time.Date(2022, time.December, 1, 0, 0, 0, 0, time.UTC), TargetReviews: 2},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubs()
+			db.groups = []Group{{Name: "Group", Scheduler: "fsrs", Retention: 0.85}}
+			err := r.SetConfig(tt.group, tt.config)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, db.groups) {
+				t.Errorf("Incorrect groups. Want %v, got %v", tt.want, db.groups)
+			}
+		})
+	}
+}
+
+func TestGetReviewSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		want    reviewing.Settings
+		wantErr error
+	}{
+		{
+			name:    "Normal",
+			group:   "Group",
+			want:    reviewing.Settings{Scheduler: "fsrs", Retention: 0.85},
+			wantErr: nil,
+		},
+		{
+			name:    "Inherited",
+			group:   "Group.SubGroup",
+			want:    reviewing.Settings{Scheduler: "leitner", Retention: 0.85, LeechThreshold: 4},
+			wantErr: nil,
+		},
+		{
+			name:    "Unset",
+			group:   "Other",
+			want:    reviewing.Settings{},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubs()
+			db.groups = []Group{
+				{Name: "Group", Scheduler: "fsrs", Retention: 0.85},
+				{Name: "Group.SubGroup", Scheduler: "leitner", LeechThreshold: 4},
+			}
+			settings, err := r.GetReviewSettings(tt.group)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, settings) {
+				t.Errorf("Incorrect settings. Want %v, got %v", tt.want, settings)
+			}
+		})
+	}
+}
+
+func TestMarkLeech(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		card    reviewing.Card
+		suspend bool
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:    "Tag",
+			group:   "Group",
+			card:    reviewing.Card{Title: "Subject1"},
+			suspend: false,
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1", Leech: true},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Suspend",
+			group:   "Group",
+			card:    reviewing.Card{Title: "Subject2"},
+			suspend: true,
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2", Suspended: true, Leech: true},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Card Not Found",
+			group:   "Group",
+			card:    reviewing.Card{Title: "Subject3"},
+			suspend: false,
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubsAndCards()
+			err := r.MarkLeech(tt.group, tt.card, tt.suspend)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, db.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, db.cards)
+			}
+		})
+	}
+}
+
+func TestSetCardSuspended(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		card    suspending.Card
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			card:  suspending.Card{Title: "Subject1"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1", Suspended: true},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Sub Group",
+			group: "Group.SubGroup",
+			card:  suspending.Card{Title: "Subject2"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2", Suspended: true},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Card Not Found",
+			group: "Group",
+			card:  suspending.Card{Title: "Subject3"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubsAndCards()
+			err := r.SetCardSuspended(tt.group, tt.card, true)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, db.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, db.cards)
+			}
+		})
+	}
+}
+
+func TestCardTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		card    tagging.Card
+		add     []string
+		remove  []string
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:  "Add",
+			group: "Group",
+			card:  tagging.Card{Title: "Subject1"},
+			add:   []string{"old", "go", "go"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1", Tags: []string{"go", "old"}},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Add And Remove",
+			group:  "",
+			card:   tagging.Card{ID: "6"},
+			add:    []string{"go", "old"},
+			remove: []string{"old", "missing"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2", Tags: []string{"go"}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Card Not Found",
+			group: "Group",
+			card:  tagging.Card{Title: "Subject3"},
+			add:   []string{"go"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubsAndCards()
+			err := r.AddCardTags(tt.group, tt.card, tt.add)
+			if err == nil && tt.remove != nil {
+				err = r.RemoveCardTags(tt.group, tt.card, tt.remove)
+			}
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, db.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, db.cards)
+			}
+		})
+	}
+}
+
+func TestMedia(t *testing.T) {
+	r, db := newRepositoryWithDbAndClockStubsAndCards()
+	diagram := attaching.Media{Name: "diagram.png", File: "abc.png"}
+
+	if err := r.AddMedia("abc.png", []byte("png")); err != nil {
+		t.Fatalf("Incorrect error. Want %v, got %v", nil, err)
+	}
+	if err := r.AddMedia("def.png", []byte("other")); err != nil {
+		t.Fatalf("Incorrect error. Want %v, got %v", nil, err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := r.AttachMedia("Group", attaching.Card{Title: "Subject1"}, diagram); err != nil {
+			t.Fatalf("Incorrect error. Want %v, got %v", nil, err)
+		}
+	}
+	if err := r.AttachMedia("Group", attaching.Card{Title: "Subject3"}, diagram); err != ErrCardNotFound {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrCardNotFound, err)
+	}
+
+	cards, _ := r.GetCards("Group")
+	want := []getting.Media{{Name: "diagram.png", File: "abc.png"}}
+	if !reflect.DeepEqual(cards[0].Media, want) {
+		t.Errorf("Incorrect card media. Want %v, got %v", want, cards[0].Media)
+	}
+	if got := string(db.files[mediaCollection+"/abc.png"]); got != "png" {
+		t.Errorf("Incorrect media data. Want %v, got %v", "png", got)
+	}
+
+	if err := r.DeleteMedia("def.png"); err != nil {
+		t.Errorf("Incorrect error. Want %v, got %v", nil, err)
+	}
+	if err := r.DeleteMedia("def.png"); err != ErrMediaNotFound {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrMediaNotFound, err)
+	}
+	if data, err := r.ReadMedia("abc.png"); err != nil || string(data) != "png" {
+		t.Errorf("Incorrect media data. Want %v, got %v (%v)", "png", string(data), err)
+	}
+	if _, err := r.ReadMedia("def.png"); err != ErrMediaNotFound {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrMediaNotFound, err)
+	}
+	files, err := r.GetMedia()
+	if want := []string{"abc.png"}; err != nil || !reflect.DeepEqual(files, want) {
+		t.Errorf("Incorrect media. Want %v, got %v (%v)", want, files, err)
+	}
+}
+
+func TestSetGroupSuspended(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1", Suspended: true},
+				{Title: "Group.Subject2", Desc: "Value2", Suspended: true},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1", Suspended: true},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2", Suspended: true},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Sub Group",
+			group: "Group.SubGroup",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1", Suspended: true},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2", Suspended: true},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Group Not Found",
+			group: "NotFound",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubsAndCards()
+			err := r.SetGroupSuspended(tt.group, true)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, db.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, db.cards)
+			}
+		})
+	}
+}
+
+func TestSetCardBuried(t *testing.T) {
+	until := time.Date(2022, time.November, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		group   string
+		card    burying.Card
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			card:  burying.Card{Title: "Subject1"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1", BuriedTill: until},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Card Not Found",
+			group: "Group",
+			card:  burying.Card{Title: "Subject3"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubsAndCards()
+			err := r.SetCardBuried(tt.group, tt.card, until)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, db.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, db.cards)
+			}
+		})
+	}
+}
+
+func TestSetGroupBuried(t *testing.T) {
+	until := time.Date(2022, time.November, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		group   string
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group.SubGroup",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1", BuriedTill: until},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2", BuriedTill: until},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Group Not Found",
+			group: "NotFound",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubsAndCards()
+			err := r.SetGroupBuried(tt.group, until)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, db.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, db.cards)
+			}
+		})
+	}
+}
+
+func TestAddReview(t *testing.T) {
+	day := time.Date(2022, time.November, 1, 12, 0, 0, 0, time.UTC)
+	reviews := []reviewing.Review{
+		{
+			Card: "Group.Subject1", Face: getting.Forward, Time: day, Grade: reviewing.Good,
+			Duration: time.Second, LastInterval: 1, Interval: 6, Scheduler: reviewing.SM2,
+		},
+		{
+			Card: "Group.Subject2", Face: getting.Reverse, Time: day.Add(time.Hour), Grade: reviewing.Again,
+			Duration: 2 * time.Second, LastInterval: 6, Interval: 1, Scheduler: reviewing.SM2,
+		},
+		{
+			Card: "Group.Subject1", Face: getting.Forward, Time: day.AddDate(0, 0, 6), Grade: reviewing.Easy,
+			LastInterval: 6, Interval: 15, Scheduler: reviewing.FSRS,
+		},
+	}
+	want := map[string][]Review{
+		"2022-11-01": {
+			{
+				Card: "Group.Subject1", Face: getting.Forward, Time: day, Grade: 2,
+				Duration: time.Second, LastInterval: 1, Interval: 6, Scheduler: reviewing.SM2,
+			},
+			{
+				Card: "Group.Subject2", Face: getting.Reverse, Time: day.Add(time.Hour), Grade: 0,
+				Duration: 2 * time.Second, LastInterval: 6, Interval: 1, Scheduler: reviewing.SM2,
+			},
+		},
+		"2022-11-07": {
+			{
+				Card: "Group.Subject1", Face: getting.Forward, Time: day.AddDate(0, 0, 6), Grade: 3,
+				LastInterval: 6, Interval: 15, Scheduler: reviewing.FSRS,
+			},
+		},
+	}
+
+	r, db := newRepositoryWithDbAndClockStubs()
+	for _, rv := range reviews {
+		if err := r.AddReview(rv); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+
+	if !reflect.DeepEqual(want, db.reviews) {
+		t.Errorf("Incorrect reviews. Want %v, got %v", want, db.reviews)
+	}
+}
+
+func TestGetReviews(t *testing.T) {
+	day := time.Date(2022, time.November, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		reviews map[string][]Review
+		want    []logging.Review
+		wantErr error
+	}{
+		{
+			name: "Normal",
+			reviews: map[string][]Review{
+				"2022-11-02": {{Card: "Group.Subject2", Time: day.AddDate(0, 0, 1), Grade: 0, Interval: 1}},
+				"2022-11-01": {
+					{Card: "Group.Subject1", Time: day, Grade: 2, Interval: 1, Scheduler: reviewing.SM2},
+					{Card: "Group.Subject2", Time: day, Grade: 3, Interval: 4, Duration: time.Second},
+				},
+			},
+			want: []logging.Review{
+				{Card: "Group.Subject1", Time: day, Grade: reviewing.Good, Interval: 1, Scheduler: reviewing.SM2},
+				{Card: "Group.Subject2", Time: day, Grade: reviewing.Easy, Interval: 4, Duration: time.Second},
+				{Card: "Group.Subject2", Time: day.AddDate(0, 0, 1), Grade: reviewing.Again, Interval: 1},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Empty Log",
+			reviews: nil,
+			want:    []logging.Review{},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubs()
+			db.reviews = tt.reviews
+			reviews, err := r.GetReviews()
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, reviews) {
+				t.Errorf("Incorrect reviews. Want %v, got %v", tt.want, reviews)
+			}
+		})
+	}
+}
+
+func TestSetDailyCount(t *testing.T) {
+	day := time.Date(2022, time.November, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		group   string
+		count   studying.DailyCount
+		want    []Group
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			count: studying.DailyCount{Day: day, New: 2, Reviews: 5},
+			want: []Group{
+				{Name: "Group", NewPerDay: 10, Studied: DailyCount{Day: day, New: 2, Reviews: 5}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "New Group",
+			group: "Group.SubGroup",
+			count: studying.DailyCount{Day: day, New: 1},
+			want: []Group{
+				{Name: "Group", NewPerDay: 10, Studied: DailyCount{Day: day.AddDate(0, 0, -1), New: 10}},
+				{Name: "Group.SubGroup", Studied: DailyCount{Day: day, New: 1}},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubs()
+			db.groups = []Group{
+				{Name: "Group", NewPerDay: 10, Studied: DailyCount{Day: day.AddDate(0, 0, -1), New: 10}},
+			}
+			err := r.SetDailyCount(tt.group, tt.count)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, db.groups) {
+				t.Errorf("Incorrect groups. Want %v, got %v", tt.want, db.groups)
+			}
+
+			count, err := r.GetDailyCount(tt.group)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.count, count) {
+				t.Errorf("Incorrect count. Want %v, got %v", tt.count, count)
+			}
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name       string
+		cards      []Card
+		paths      []string
+		schema     *Schema
+		want       []Card
+		wantSchema *Schema
+	}{
+		{
+			name: "Card IDs",
+			cards: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Subject1", Desc: "Value1"},
+			},
+			paths:  []string{"", "", "Group.SubGroup.Subject1"},
+			schema: nil,
+			want: []Card{
+				{ID: "1", Title: "Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.Subject1", Desc: "Value1"},
+				{ID: "2", Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+			},
+			wantSchema: &Schema{Version: 1},
+		},
+		{
+			name: "Card Paths",
+			cards: []Card{
+				{ID: "1", Title: "Subject1", Desc: "Value1"},
+				{ID: "2", Title: "Subject1", Desc: "Value1"},
+				{ID: "3", Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+			},
+			paths:  []string{"", "Group.Subject1"},
+			schema: nil,
+			want: []Card{
+				{ID: "1", Title: "Subject1", Desc: "Value1"},
+				{ID: "2", Title: "Group.Subject1", Desc: "Value1"},
+				{ID: "3", Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+			},
+			wantSchema: &Schema{Version: 1},
+		},
+		{
+			name: "Already Migrated",
+			cards: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+			},
+			schema: &Schema{Version: 1},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+			},
+			wantSchema: &Schema{Version: 1},
+		},
+		{
+			name:       "Empty Store",
+			cards:      nil,
+			schema:     nil,
+			want:       nil,
+			wantSchema: &Schema{Version: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, db := newRepositoryWithDbAndClockStubs()
+			db.cards = tt.cards
+			db.paths = tt.paths
+			db.schema = tt.schema
+
+			if err := r.migrate(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(tt.want, db.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, db.cards)
+			}
+
+			for i, c := range db.cards {
+				if p := db.cardPath(i); p != c.Title {
+					t.Errorf("Incorrect card path. Want %v, got %v", c.Title, p)
+				}
+			}
+
+			if !reflect.DeepEqual(tt.wantSchema, db.schema) {
+				t.Errorf("Incorrect schema. Want %v, got %v", tt.wantSchema, db.schema)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package json
+
+import "time"
+
+type Review struct {
+	Card         string
+	Face         string
+	Time         time.Time
+	Grade        int
+	Duration     time.Duration
+	LastInterval int
+	Interval     int
+	Scheduler    string
+}
@@ -0,0 +1,8 @@
+package memory
+
+// Media is a file attached to a card, under its original name and the name
+// its data is kept under.
+type Media struct {
+	Name string
+	File string
+}
@@ -0,0 +1,13 @@
+package memory
+
+type NoteType struct {
+	Name      string
+	Fields    []string
+	Templates []Template
+}
+
+type Template struct {
+	Name  string
+	Front string
+	Back  string
+}
@@ -2,11 +2,21 @@ package memory
 
 import (
 	"errors"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/jmcveigh55/flash/pkg/core/adding"
+	"github.com/jmcveigh55/flash/pkg/core/attaching"
+	"github.com/jmcveigh55/flash/pkg/core/burying"
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
 	"github.com/jmcveigh55/flash/pkg/core/deleting"
 	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/logging"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/core/studying"
+	"github.com/jmcveigh55/flash/pkg/core/suspending"
+	"github.com/jmcveigh55/flash/pkg/core/tagging"
 	"github.com/jmcveigh55/flash/pkg/core/updating"
 	"github.com/jmcveigh55/flash/pkg/storage"
 )
@@ -14,29 +24,38 @@ import (
 var (
 	ErrCardFound     = errors.New("card already exists")
 	ErrCardNotFound  = errors.New("card not found")
-	ErrGroupNotFound = errors.New("group not found")
+	ErrGroupNotFound = getting.ErrGroupNotFound
+	ErrGroupFound    = errors.New("group already exists")
+	ErrClozeNotFound = errors.New("cloze card has no deletions")
+	ErrFaceNotFound  = errors.New("card has no such face")
+	ErrNoteCard      = errors.New("note cards are made from their note's fields and cannot be updated")
+
+	ErrNoteTypeFound    = errors.New("note type already exists")
+	ErrNoteTypeNotFound = errors.New("note type not found")
+
+	ErrMediaNotFound = errors.New("media not found")
 )
 
 type repository struct {
-	cards []Card
+	cards     []Card
+	groups    []Group
+	reviews   []Review
+	noteTypes []NoteType
+	// media holds the data of attached files by the name it is kept under.
+	media map[string][]byte
 	clock storage.Clock
+	ids   storage.IDGenerator
 }
 
 func New() *repository {
 	r := &repository{}
 	r.clock = storage.NewClock()
+	r.ids = storage.NewIDGenerator()
 	return r
 }
 
-func getCardPath(g, t string) string {
-	if g == "" {
-		return t
-	}
-	return g + "." + t
-}
-
 func (r *repository) AddCard(g string, c adding.Card) error {
-	cardPath := getCardPath(g, c.Title)
+	cardPath := storage.JoinPath(g, c.Title)
 
 	for _, card := range r.cards {
 		if card.Title == cardPath {
@@ -48,25 +67,90 @@ func (r *repository) AddCard(g string, c adding.Card) error {
 	r.cards = append(
 		r.cards,
 		Card{
-			Title:   cardPath,
-			Desc:    c.Desc,
-			Created: t,
-			Updated: t,
+			ID:         r.ids.NewID(),
+			Title:      cardPath,
+			Desc:       c.Desc,
+			Answers:    c.Answers,
+			Reversible: c.Reversible,
+			Cloze:      c.Cloze,
+			Tags:       storage.AddTags(nil, c.Tags),
+			Created:    t,
+			Updated:    t,
 		},
 	)
 	return nil
 }
 
-func (r *repository) DeleteCard(g string, c deleting.Card) error {
-	cardPath := getCardPath(g, c.Title)
+func (r *repository) AddNoteType(t adding.NoteType) error {
+	if _, ok := r.noteType(t.Name); ok {
+		return ErrNoteTypeFound
+	}
+	r.noteTypes = append(r.noteTypes, toNoteType(t))
+	return nil
+}
+
+func (r *repository) GetNoteType(name string) (adding.NoteType, error) {
+	t, ok := r.noteType(name)
+	if !ok {
+		return adding.NoteType{}, ErrNoteTypeNotFound
+	}
+	return toAddingNoteType(t), nil
+}
+
+func (r *repository) noteType(name string) (NoteType, bool) {
+	for _, t := range r.noteTypes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return NoteType{}, false
+}
+
+func (r *repository) AddNote(g string, n adding.Note) error {
+	cardPath := storage.JoinPath(g, n.Title)
+	if r.findCard("", "", cardPath) != -1 {
+		return ErrCardFound
+	}
+
+	t := r.clock.Now()
+	r.cards = append(r.cards, Card{
+		ID:       r.ids.NewID(),
+		Title:    cardPath,
+		Tags:     storage.AddTags(nil, n.Tags),
+		NoteType: n.Type,
+		Fields:   n.Fields,
+		Created:  t,
+		Updated:  t,
+	})
+	return nil
+}
+
+// addTemplates gives the note cards the templates of their type.
+func (r *repository) addTemplates(cards []getting.Card) {
+	for _, c := range cards {
+		if c.Note == nil {
+			continue
+		}
+		if t, ok := r.noteType(c.Note.Type); ok {
+			c.Note.Templates = toGettingTemplates(t.Templates)
+		}
+	}
+}
 
-	index := -1
+// findCard returns the index of the card with the ID, or with the title in
+// the group when the ID is empty, or -1 if there is none.
+func (r *repository) findCard(g, id, title string) int {
+	cardPath := storage.JoinPath(g, title)
 	for i, card := range r.cards {
-		if card.Title == cardPath {
-			index = i
+		if (id != "" && card.ID == id) || (id == "" && card.Title == cardPath) {
+			return i
 		}
 	}
+	return -1
+}
 
+func (r *repository) DeleteCard(g string, c deleting.Card) error {
+	index := r.findCard(g, c.ID, c.Title)
 	if index == -1 {
 		return ErrCardNotFound
 	}
@@ -81,10 +165,7 @@ func (r *repository) GetCards(g string) ([]getting.Card, error) {
 		items := strings.Split(c.Title, ".")
 		p := strings.Join(items[:len(items)-1], ".") // Strip card from title
 		if p == g {
-			cards = append(cards, getting.Card{
-				Title: c.Title,
-				Desc:  c.Desc,
-			})
+			cards = append(cards, toGettingCard(c))
 		}
 	}
 
@@ -92,6 +173,7 @@ func (r *repository) GetCards(g string) ([]getting.Card, error) {
 		return cards, ErrGroupNotFound
 	}
 
+	r.addTemplates(cards)
 	return cards, nil
 }
 
@@ -99,10 +181,7 @@ func (r *repository) GetAllCards(g string) ([]getting.Card, error) {
 	var cards []getting.Card
 	for _, c := range r.cards {
 		if strings.HasPrefix(c.Title, g) {
-			cards = append(cards, getting.Card{
-				Title: c.Title,
-				Desc:  c.Desc,
-			})
+			cards = append(cards, toGettingCard(c))
 		}
 	}
 
@@ -110,18 +189,491 @@ func (r *repository) GetAllCards(g string) ([]getting.Card, error) {
 		return cards, ErrGroupNotFound
 	}
 
+	r.addTemplates(cards)
 	return cards, nil
 }
 
 func (r *repository) UpdateCard(g string, c updating.Card) error {
-	cardPath := getCardPath(g, c.Title)
+	i := r.findCard(g, c.ID, c.Title)
+	if i == -1 {
+		return ErrCardNotFound
+	}
 
-	for i := range r.cards {
-		if r.cards[i].Title == cardPath {
-			r.cards[i].Desc = c.Desc
-			r.cards[i].Updated = r.clock.Now()
+	if r.cards[i].NoteType != "" {
+		return ErrNoteCard
+	}
+	if r.cards[i].Cloze {
+		if len(c.Clozes) == 0 {
+			return ErrClozeNotFound
+		}
+		keepSchedules(&r.cards[i], c.Clozes)
+	}
+	if old := r.cards[i]; storage.Revised(old.Desc, old.Answers, c.Desc, c.Answers) {
+		r.cards[i].Revisions = append(old.Revisions, Revision{old.Desc, old.Answers, old.Updated})
+	}
+	r.cards[i].Desc = c.Desc
+	if c.Answers != nil {
+		r.cards[i].Answers = c.Answers
+	}
+	if c.Tags != nil {
+		r.cards[i].Tags = storage.AddTags(nil, c.Tags)
+	}
+	r.cards[i].Updated = r.clock.Now()
+	return nil
+}
+
+// MoveCard gives the card a new path, keeping everything else about it.
+func (r *repository) MoveCard(src, dst string) error {
+	index := r.findCard("", "", src)
+	if index == -1 {
+		return ErrCardNotFound
+	}
+	if r.findCard("", "", dst) != -1 {
+		return ErrCardFound
+	}
+
+	r.cards[index].Title = dst
+	r.moveReviews(src, dst, false)
+	return nil
+}
+
+// MoveGroup moves every card and group setting under the source group to
+// the destination.
+func (r *repository) MoveGroup(src, dst string) error {
+	var moved []int
+	for i, card := range r.cards {
+		if strings.HasPrefix(card.Title, dst+".") {
+			return ErrGroupFound
+		}
+		if strings.HasPrefix(card.Title, src+".") {
+			moved = append(moved, i)
+		}
+	}
+	if len(moved) == 0 {
+		return ErrGroupNotFound
+	}
+
+	for _, i := range moved {
+		r.cards[i].Title = dst + strings.TrimPrefix(r.cards[i].Title, src)
+	}
+	for i, group := range r.groups {
+		if group.Name == src || strings.HasPrefix(group.Name, src+".") {
+			r.groups[i].Name = dst + strings.TrimPrefix(group.Name, src)
+		}
+	}
+	r.moveReviews(src, dst, true)
+	return nil
+}
+
+// moveReviews logs the reviews of moved cards under their new paths, so
+// they keep their history.
+func (r *repository) moveReviews(src, dst string, group bool) {
+	for i := range r.reviews {
+		r.reviews[i].Card, _ = storage.MovedPath(r.reviews[i].Card, src, dst, group)
+	}
+}
+
+func (r *repository) GetSchedule(g string, c reviewing.Card) (reviewing.Card, error) {
+	i := r.findCard(g, "", c.Title)
+	if i == -1 {
+		return reviewing.Card{}, ErrCardNotFound
+	}
+
+	if !toGettingCard(r.cards[i]).HasFace(c.Face) {
+		return reviewing.Card{}, ErrFaceNotFound
+	}
+	return toReviewingCard(c, r.cards[i].Schedules[c.Face]), nil
+}
+
+func (r *repository) UpdateSchedule(g string, c reviewing.Card) error {
+	return r.updateCard(g, "", c.Title, func(card *Card) {
+		setSchedule(card, c)
+	})
+}
+
+func (r *repository) MarkLeech(g string, c reviewing.Card, suspend bool) error {
+	return r.updateCard(g, "", c.Title, func(card *Card) {
+		card.Leech = true
+		if suspend {
+			card.Suspended = true
+		}
+	})
+}
+
+func (r *repository) SetCardSuspended(g string, c suspending.Card, suspended bool) error {
+	return r.updateCard(g, "", c.Title, func(card *Card) {
+		card.Suspended = suspended
+	})
+}
+
+func (r *repository) SetGroupSuspended(g string, suspended bool) error {
+	return r.updateGroupCards(g, func(card *Card) {
+		card.Suspended = suspended
+	})
+}
+
+func (r *repository) SetCardBuried(g string, c burying.Card, t time.Time) error {
+	return r.updateCard(g, "", c.Title, func(card *Card) {
+		card.BuriedTill = t
+	})
+}
+
+func (r *repository) SetGroupBuried(g string, t time.Time) error {
+	return r.updateGroupCards(g, func(card *Card) {
+		card.BuriedTill = t
+	})
+}
+
+func (r *repository) AddCardTags(g string, c tagging.Card, tags []string) error {
+	i := r.findCard(g, c.ID, c.Title)
+	if i == -1 {
+		return ErrCardNotFound
+	}
+	r.cards[i].Tags = storage.AddTags(r.cards[i].Tags, tags)
+	return nil
+}
+
+func (r *repository) RemoveCardTags(g string, c tagging.Card, tags []string) error {
+	i := r.findCard(g, c.ID, c.Title)
+	if i == -1 {
+		return ErrCardNotFound
+	}
+	r.cards[i].Tags = storage.RemoveTags(r.cards[i].Tags, tags)
+	return nil
+}
+
+// AddMedia keeps a copy of the data under the file name, unless it already
+// is.
+func (r *repository) AddMedia(file string, data []byte) error {
+	if r.media == nil {
+		r.media = map[string][]byte{}
+	}
+	if _, ok := r.media[file]; !ok {
+		r.media[file] = append([]byte(nil), data...)
+	}
+	return nil
+}
+
+func (r *repository) AttachMedia(g string, c attaching.Card, m attaching.Media) error {
+	i := r.findCard(g, c.ID, c.Title)
+	if i == -1 {
+		return ErrCardNotFound
+	}
+	for _, media := range r.cards[i].Media {
+		if media.File == m.File {
 			return nil
 		}
 	}
-	return ErrCardNotFound
+	r.cards[i].Media = append(r.cards[i].Media, Media{Name: m.Name, File: m.File})
+	return nil
+}
+
+// GetMedia returns the file names of the media kept, in order.
+func (r *repository) GetMedia() ([]string, error) {
+	files := []string{}
+	for f := range r.media {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ReadMedia returns a copy of the data kept under the file name.
+func (r *repository) ReadMedia(file string) ([]byte, error) {
+	data, ok := r.media[file]
+	if !ok {
+		return nil, ErrMediaNotFound
+	}
+	return append([]byte(nil), data...), nil
+}
+
+func (r *repository) DeleteMedia(file string) error {
+	if _, ok := r.media[file]; !ok {
+		return ErrMediaNotFound
+	}
+	delete(r.media, file)
+	return nil
+}
+
+// updateCard applies f to the card found by findCard.
+func (r *repository) updateCard(g, id, title string, f func(*Card)) error {
+	i := r.findCard(g, id, title)
+	if i == -1 {
+		return ErrCardNotFound
+	}
+
+	f(&r.cards[i])
+	return nil
+}
+
+// updateGroupCards applies f to every card under the group.
+func (r *repository) updateGroupCards(g string, f func(*Card)) error {
+	found := false
+	for i := range r.cards {
+		if g == "" || strings.HasPrefix(r.cards[i].Title, g+".") {
+			f(&r.cards[i])
+			found = true
+		}
+	}
+	if !found {
+		return ErrGroupNotFound
+	}
+	return nil
+}
+
+func (r *repository) AddReview(rv reviewing.Review) error {
+	r.reviews = append(r.reviews, Review{
+		Card:         rv.Card,
+		Face:         rv.Face,
+		Time:         rv.Time,
+		Grade:        int(rv.Grade),
+		Duration:     rv.Duration,
+		LastInterval: rv.LastInterval,
+		Interval:     rv.Interval,
+		Scheduler:    rv.Scheduler,
+	})
+	return nil
+}
+
+func (r *repository) GetReviews() ([]logging.Review, error) {
+	reviews := []logging.Review{}
+	for _, rv := range r.reviews {
+		reviews = append(reviews, toLoggingReview(rv))
+	}
+	return reviews, nil
+}
+
+func (r *repository) getGroup(g string) Group {
+	for _, group := range r.groups {
+		if group.Name == g {
+			return group
+		}
+	}
+	return Group{Name: g}
+}
+
+func (r *repository) setGroup(group Group) {
+	for i := range r.groups {
+		if r.groups[i].Name == group.Name {
+			r.groups[i] = group
+			return
+		}
+	}
+	r.groups = append(r.groups, group)
+}
+
+// GetReviewSettings returns the group's review settings, inheriting any
+// that are unset from its parent groups.
+func (r *repository) GetReviewSettings(g string) (reviewing.Settings, error) {
+	c, err := configuring.Resolve(r.GetConfig, g)
+	if err != nil {
+		return reviewing.Settings{}, err
+	}
+	return reviewing.Settings{
+		Scheduler:      c.Scheduler,
+		Weights:        c.Weights,
+		Retention:      c.Retention,
+		Boxes:          c.Boxes,
+		LeechThreshold: c.LeechThreshold,
+		LeechAction:    c.LeechAction,
+		Target:         c.Target,
+		TargetReviews:  c.TargetReviews,
+	}, nil
+}
+
+func (r *repository) GetDailyCount(g string) (studying.DailyCount, error) {
+	group := r.getGroup(g)
+	return studying.DailyCount{
+		Day:     group.Studied.Day,
+		New:     group.Studied.New,
+		Reviews: group.Studied.Reviews,
+	}, nil
+}
+
+func (r *repository) SetDailyCount(g string, c studying.DailyCount) error {
+	group := r.getGroup(g)
+	group.Studied = DailyCount{
+		Day:     c.Day,
+		New:     c.New,
+		Reviews: c.Reviews,
+	}
+	r.setGroup(group)
+	return nil
+}
+
+func (r *repository) GetConfig(g string) (configuring.Config, error) {
+	group := r.getGroup(g)
+	return configuring.Config{
+		Scheduler:      group.Scheduler,
+		Weights:        group.Weights,
+		Retention:      group.Retention,
+		Boxes:          group.Boxes,
+		NewPerDay:      group.NewPerDay,
+		ReviewsPerDay:  group.ReviewsPerDay,
+		LeechThreshold: group.LeechThreshold,
+		LeechAction:    group.LeechAction,
+		Target:         group.Target,
+		TargetReviews:  group.TargetReviews,
+	}, nil
+}
+
+func (r *repository) SetConfig(g string, c configuring.Config) error {
+	group := r.getGroup(g)
+	group.Scheduler = c.Scheduler
+	group.Weights = c.Weights
+	group.Retention = c.Retention
+	group.Boxes = c.Boxes
+	group.NewPerDay = c.NewPerDay
+	group.ReviewsPerDay = c.ReviewsPerDay
+	group.LeechThreshold = c.LeechThreshold
+	group.LeechAction = c.LeechAction
+	group.Target = c.Target
+	group.TargetReviews = c.TargetReviews
+	r.setGroup(group)
+	return nil
+}
+
+func toGettingCard(c Card) getting.Card {
+	var schedules map[string]getting.Schedule
+	for f, s := range c.Schedules {
+		if schedules == nil {
+			schedules = make(map[string]getting.Schedule)
+		}
+		schedules[f] = getting.Schedule{
+			Due:      s.Due,
+			Interval: s.Interval,
+			Box:      s.Box,
+			Lapses:   s.Lapses,
+		}
+	}
+	return getting.Card{
+		ID:         c.ID,
+		Title:      c.Title,
+		Desc:       c.Desc,
+		Answers:    c.Answers,
+		Reversible: c.Reversible,
+		Cloze:      c.Cloze,
+		Created:    c.Created,
+		Updated:    c.Updated,
+		Schedules:  schedules,
+		Suspended:  c.Suspended,
+		BuriedTill: c.BuriedTill,
+		Leech:      c.Leech,
+		Tags:       c.Tags,
+		Note:       toGettingNote(c),
+		Media:      toGettingMedia(c.Media),
+		Revisions:  toGettingRevisions(c.Revisions),
+	}
+}
+
+func toGettingRevisions(revisions []Revision) []getting.Revision {
+	var rs []getting.Revision
+	for _, r := range revisions {
+		rs = append(rs, getting.Revision{Desc: r.Desc, Answers: r.Answers, Time: r.Time})
+	}
+	return rs
+}
+
+func toGettingMedia(media []Media) []getting.Media {
+	var ms []getting.Media
+	for _, m := range media {
+		ms = append(ms, getting.Media{Name: m.Name, File: m.File})
+	}
+	return ms
+}
+
+// toGettingNote returns the note of a note card, without its type's
+// templates, or nil for other cards.
+func toGettingNote(c Card) *getting.Note {
+	if c.NoteType == "" {
+		return nil
+	}
+	return &getting.Note{Type: c.NoteType, Fields: c.Fields}
+}
+
+func toGettingTemplates(templates []Template) []getting.Template {
+	var ts []getting.Template
+	for _, t := range templates {
+		ts = append(ts, getting.Template{Name: t.Name, Front: t.Front, Back: t.Back})
+	}
+	return ts
+}
+
+func toNoteType(t adding.NoteType) NoteType {
+	var templates []Template
+	for _, tmpl := range t.Templates {
+		templates = append(templates, Template{Name: tmpl.Name, Front: tmpl.Front, Back: tmpl.Back})
+	}
+	return NoteType{Name: t.Name, Fields: t.Fields, Templates: templates}
+}
+
+func toAddingNoteType(t NoteType) adding.NoteType {
+	var templates []adding.Template
+	for _, tmpl := range t.Templates {
+		templates = append(templates, adding.Template{Name: tmpl.Name, Front: tmpl.Front, Back: tmpl.Back})
+	}
+	return adding.NoteType{Name: t.Name, Fields: t.Fields, Templates: templates}
+}
+
+func toReviewingCard(c reviewing.Card, s Schedule) reviewing.Card {
+	return reviewing.Card{
+		Title: c.Title,
+		Face:  c.Face,
+		Due:   s.Due,
+		State: reviewing.State{
+			Interval:    s.Interval,
+			EaseFactor:  s.EaseFactor,
+			Repetitions: s.Repetitions,
+			Stability:   s.Stability,
+			Difficulty:  s.Difficulty,
+			Box:         s.Box,
+			Reviewed:    s.Reviewed,
+			Lapses:      s.Lapses,
+			Relearning:  s.Relearning,
+		},
+	}
+}
+
+func setSchedule(card *Card, c reviewing.Card) {
+	if card.Schedules == nil {
+		card.Schedules = make(map[string]Schedule)
+	}
+	card.Schedules[c.Face] = Schedule{
+		Due:         c.Due,
+		Interval:    c.State.Interval,
+		EaseFactor:  c.State.EaseFactor,
+		Repetitions: c.State.Repetitions,
+		Stability:   c.State.Stability,
+		Difficulty:  c.State.Difficulty,
+		Box:         c.State.Box,
+		Reviewed:    c.State.Reviewed,
+		Lapses:      c.State.Lapses,
+		Relearning:  c.State.Relearning,
+	}
+}
+
+func toLoggingReview(rv Review) logging.Review {
+	return logging.Review{
+		Card:         rv.Card,
+		Face:         rv.Face,
+		Time:         rv.Time,
+		Grade:        reviewing.Grade(rv.Grade),
+		Duration:     rv.Duration,
+		LastInterval: rv.LastInterval,
+		Interval:     rv.Interval,
+		Scheduler:    rv.Scheduler,
+	}
+}
+
+// keepSchedules drops the schedules of the faces that are not listed.
+func keepSchedules(card *Card, faces []string) {
+	keep := map[string]bool{}
+	for _, f := range faces {
+		keep[f] = true
+	}
+	for f := range card.Schedules {
+		if !keep[f] {
+			delete(card.Schedules, f)
+		}
+	}
 }
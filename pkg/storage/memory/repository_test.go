@@ -2,13 +2,23 @@ package memory
 
 import (
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/jmcveigh55/flash/pkg/core/adding"
+	"github.com/jmcveigh55/flash/pkg/core/attaching"
+	"github.com/jmcveigh55/flash/pkg/core/burying"
+	"github.com/jmcveigh55/flash/pkg/core/configuring"
 	"github.com/jmcveigh55/flash/pkg/core/deleting"
 	"github.com/jmcveigh55/flash/pkg/core/getting"
+	"github.com/jmcveigh55/flash/pkg/core/logging"
+	"github.com/jmcveigh55/flash/pkg/core/reviewing"
+	"github.com/jmcveigh55/flash/pkg/core/studying"
+	"github.com/jmcveigh55/flash/pkg/core/suspending"
+	"github.com/jmcveigh55/flash/pkg/core/tagging"
 	"github.com/jmcveigh55/flash/pkg/core/updating"
+	"github.com/jmcveigh55/flash/pkg/storage"
 )
 
 type clockStub struct{}
@@ -17,9 +27,20 @@ func (c *clockStub) Now() time.Time {
 	return time.Time{}
 }
 
+// idGeneratorStub numbers the IDs it generates from 1.
+type idGeneratorStub struct {
+	n int
+}
+
+func (g *idGeneratorStub) NewID() string {
+	g.n++
+	return strconv.Itoa(g.n)
+}
+
 func newRepositoryWithClockStub() *repository {
 	r := &repository{}
 	r.clock = &clockStub{}
+	r.ids = &idGeneratorStub{}
 	return r
 }
 
@@ -31,7 +52,7 @@ func newRepositoryWithClockStubAndCards() *repository {
 		{Title: "Group.Subject1", Desc: "Value1"},
 		{Title: "Group.Subject2", Desc: "Value2"},
 		{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-		{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+		{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 	}
 	return r
 }
@@ -48,28 +69,49 @@ func TestAddCardSingle(t *testing.T) {
 			name:    "Normal",
 			group:   "Group",
 			card:    adding.Card{Title: "Subject1", Desc: "Value1"},
-			want:    []Card{{Title: "Group.Subject1", Desc: "Value1"}},
+			want:    []Card{{ID: "1", Title: "Group.Subject1", Desc: "Value1"}},
+			wantErr: nil,
+		},
+		{
+			name:    "Tags",
+			group:   "Group",
+			card:    adding.Card{Title: "Subject1", Desc: "Value1", Tags: []string{"old", "go", "old"}},
+			want:    []Card{{ID: "1", Title: "Group.Subject1", Desc: "Value1", Tags: []string{"go", "old"}}},
 			wantErr: nil,
 		},
 		{
 			name:    "Empty Title",
 			group:   "Group",
 			card:    adding.Card{Title: "", Desc: "Value1"},
-			want:    []Card{{Title: "Group.", Desc: "Value1"}},
+			want:    []Card{{ID: "1", Title: "Group.", Desc: "Value1"}},
 			wantErr: nil,
 		},
 		{
 			name:    "Empty Desc",
 			group:   "Group",
 			card:    adding.Card{Title: "Subject1", Desc: ""},
-			want:    []Card{{Title: "Group.Subject1", Desc: ""}},
+			want:    []Card{{ID: "1", Title: "Group.Subject1", Desc: ""}},
 			wantErr: nil,
 		},
 		{
 			name:    "Empty Group",
 			group:   "",
 			card:    adding.Card{Title: "Subject1", Desc: ""},
-			want:    []Card{{Title: "Subject1", Desc: ""}},
+			want:    []Card{{ID: "1", Title: "Subject1", Desc: ""}},
+			wantErr: nil,
+		},
+		{
+			name:    "Cloze",
+			group:   "Group",
+			card:    adding.Card{Title: "Subject1", Desc: "{{c1::Value1}}", Cloze: true},
+			want:    []Card{{ID: "1", Title: "Group.Subject1", Desc: "{{c1::Value1}}", Cloze: true}},
+			wantErr: nil,
+		},
+		{
+			name:    "Reversible",
+			group:   "Group",
+			card:    adding.Card{Title: "Subject1", Desc: "Value1", Reversible: true},
+			want:    []Card{{ID: "1", Title: "Group.Subject1", Desc: "Value1", Reversible: true}},
 			wantErr: nil,
 		},
 	}
@@ -106,8 +148,8 @@ func TestAddCardMultiple(t *testing.T) {
 				{Title: "Subject2", Desc: "Value2"},
 			},
 			want: []Card{
-				{Title: "Group.Subject1", Desc: "Value1"},
-				{Title: "Group.Subject2", Desc: "Value2"},
+				{ID: "1", Title: "Group.Subject1", Desc: "Value1"},
+				{ID: "2", Title: "Group.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -119,7 +161,7 @@ func TestAddCardMultiple(t *testing.T) {
 				{Title: "Subject1", Desc: "Value2"},
 			},
 			want: []Card{
-				{Title: "Group.Subject1", Desc: "Value1"},
+				{ID: "1", Title: "Group.Subject1", Desc: "Value1"},
 			},
 			wantErr: ErrCardFound,
 		},
@@ -131,8 +173,8 @@ func TestAddCardMultiple(t *testing.T) {
 				{Title: "Subject2", Desc: "Value2"},
 			},
 			want: []Card{
-				{Title: "Subject1", Desc: "Value1"},
-				{Title: "Subject2", Desc: "Value2"},
+				{ID: "1", Title: "Subject1", Desc: "Value1"},
+				{ID: "2", Title: "Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -174,10 +216,37 @@ func TestDeleteCardSingle(t *testing.T) {
 				{Title: "Subject2", Desc: "Value2"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "By ID",
+			group: "Group",
+			card:  deleting.Card{ID: "6"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
 			},
 			wantErr: nil,
 		},
+		{
+			name:  "ID Not Found",
+			group: "Group",
+			card:  deleting.Card{ID: "7", Title: "Subject1"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrCardNotFound,
+		},
 		{
 			name:  "Card not found",
 			group: "Group",
@@ -188,7 +257,7 @@ func TestDeleteCardSingle(t *testing.T) {
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: ErrCardNotFound,
 		},
@@ -201,7 +270,7 @@ func TestDeleteCardSingle(t *testing.T) {
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -240,7 +309,7 @@ func TestDeleteCardMultiple(t *testing.T) {
 				{Title: "Subject1", Desc: "Value1"},
 				{Title: "Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 		},
 		{
@@ -269,7 +338,7 @@ func TestDeleteCardMultiple(t *testing.T) {
 				{Title: "Subject1", Desc: "Value1"},
 				{Title: "Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 		},
 		{
@@ -283,7 +352,7 @@ func TestDeleteCardMultiple(t *testing.T) {
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 		},
 	}
@@ -324,7 +393,7 @@ func TestGetCards(t *testing.T) {
 			group: "Group.SubGroup",
 			want: []getting.Card{
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -369,7 +438,7 @@ func TestGetAllCards(t *testing.T) {
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -378,7 +447,7 @@ func TestGetAllCards(t *testing.T) {
 			group: "Group.SubGroup",
 			want: []getting.Card{
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -391,7 +460,7 @@ func TestGetAllCards(t *testing.T) {
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -428,10 +497,24 @@ func TestUpdateCardSingle(t *testing.T) {
 			want: []Card{
 				{Title: "Subject1", Desc: "Value1"},
 				{Title: "Subject2", Desc: "Value2"},
-				{Title: "Group.Subject1", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value2", Revisions: []Revision{{Desc: "Value1"}}},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Answers",
+			group: "Group",
+			card:  updating.Card{Title: "Subject1", Desc: "Value2", Answers: []string{"Value3"}},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value2", Answers: []string{"Value3"}, Revisions: []Revision{{Desc: "Value1"}}},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -444,8 +527,8 @@ func TestUpdateCardSingle(t *testing.T) {
 				{Title: "Subject2", Desc: "Value2"},
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
-				{Title: "Group.SubGroup.Subject1", Desc: "Value2"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value2", Revisions: []Revision{{Desc: "Value1"}}},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: nil,
 		},
@@ -456,10 +539,24 @@ func TestUpdateCardSingle(t *testing.T) {
 			want: []Card{
 				{Title: "Subject1", Desc: "Value1"},
 				{Title: "Subject2", Desc: "Value2"},
-				{Title: "Group.Subject1", Desc: ""},
+				{Title: "Group.Subject1", Desc: "", Revisions: []Revision{{Desc: "Value1"}}},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "By ID",
+			group: "Group",
+			card:  updating.Card{ID: "6", Desc: "Value3"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value3", Revisions: []Revision{{Desc: "Value2"}}},
 			},
 			wantErr: nil,
 		},
@@ -473,7 +570,7 @@ func TestUpdateCardSingle(t *testing.T) {
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 			wantErr: ErrCardNotFound,
 		},
@@ -512,10 +609,10 @@ func TestUpdateCardMultiple(t *testing.T) {
 			want: []Card{
 				{Title: "Subject1", Desc: "Value1"},
 				{Title: "Subject2", Desc: "Value2"},
-				{Title: "Group.Subject1", Desc: "Value2"},
-				{Title: "Group.Subject2", Desc: "Value3"},
+				{Title: "Group.Subject1", Desc: "Value2", Revisions: []Revision{{Desc: "Value1"}}},
+				{Title: "Group.Subject2", Desc: "Value3", Revisions: []Revision{{Desc: "Value2"}}},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 		},
 		{
@@ -530,8 +627,8 @@ func TestUpdateCardMultiple(t *testing.T) {
 				{Title: "Subject2", Desc: "Value2"},
 				{Title: "Group.Subject1", Desc: "Value1"},
 				{Title: "Group.Subject2", Desc: "Value2"},
-				{Title: "Group.SubGroup.Subject1", Desc: "Value2"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value3"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value2", Revisions: []Revision{{Desc: "Value1"}}},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value3", Revisions: []Revision{{Desc: "Value2"}}},
 			},
 		},
 		{
@@ -544,10 +641,10 @@ func TestUpdateCardMultiple(t *testing.T) {
 			want: []Card{
 				{Title: "Subject1", Desc: "Value1"},
 				{Title: "Subject2", Desc: "Value2"},
-				{Title: "Group.Subject1", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value2", Revisions: []Revision{{Desc: "Value1"}}},
 				{Title: "Group.Subject2", Desc: "Value2"},
 				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
-				{Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
 			},
 		},
 	}
@@ -566,3 +663,1227 @@ func TestUpdateCardMultiple(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateCardCloze(t *testing.T) {
+	card := func() Card {
+		return Card{
+			Title: "Group.Subject1", Desc: "{{c1::Value1}} {{c2::Value2}}", Cloze: true,
+			Schedules: map[string]Schedule{"c1": {Interval: 1}, "c2": {Interval: 2}},
+		}
+	}
+	tests := []struct {
+		name    string
+		card    updating.Card
+		want    []Card
+		wantErr error
+	}{
+		{
+			name: "Add Cloze",
+			card: updating.Card{Title: "Subject1", Desc: "{{c1::Value1}} {{c2::Value2}} {{c3::Value3}}", Clozes: []string{"c1", "c2", "c3"}},
+			want: []Card{{
+				Title: "Group.Subject1", Desc: "{{c1::Value1}} {{c2::Value2}} {{c3::Value3}}", Cloze: true,
+				Schedules: map[string]Schedule{"c1": {Interval: 1}, "c2": {Interval: 2}},
+				Revisions: []Revision{{Desc: "{{c1::Value1}} {{c2::Value2}}"}},
+			}},
+			wantErr: nil,
+		},
+		{
+			name: "Remove Cloze",
+			card: updating.Card{Title: "Subject1", Desc: "Value1 {{c2::Value2}}", Clozes: []string{"c2"}},
+			want: []Card{{
+				Title: "Group.Subject1", Desc: "Value1 {{c2::Value2}}", Cloze: true,
+				Schedules: map[string]Schedule{"c2": {Interval: 2}},
+				Revisions: []Revision{{Desc: "{{c1::Value1}} {{c2::Value2}}"}},
+			}},
+			wantErr: nil,
+		},
+		{
+			name:    "No Clozes",
+			card:    updating.Card{Title: "Subject1", Desc: "Value1"},
+			want:    []Card{card()},
+			wantErr: ErrClozeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStub()
+			r.cards = []Card{card()}
+			err := r.UpdateCard("Group", tt.card)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, r.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, r.cards)
+			}
+		})
+	}
+}
+
+func TestUpdateCardNote(t *testing.T) {
+	card := Card{
+		Title: "Group.Subject1", NoteType: "Basic",
+		Fields: map[string]string{"Front": "Subject1", "Back": "Value1"},
+	}
+	r := newRepositoryWithClockStub()
+	r.cards = []Card{card}
+	err := r.UpdateCard("Group", updating.Card{Title: "Subject1", Desc: "Value2"})
+
+	if err != ErrNoteCard {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrNoteCard, err)
+	}
+	if want := []Card{card}; !reflect.DeepEqual(want, r.cards) {
+		t.Errorf("Incorrect cards. Want %v, got %v", want, r.cards)
+	}
+}
+
+func TestMoveCard(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		dst         string
+		want        []Card
+		wantReviews []Review
+		wantErr     error
+	}{
+		{
+			name: "Normal",
+			src:  "Group.SubGroup.Subject2",
+			dst:  "Subject3",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Subject3", Desc: "Value2"},
+			},
+			wantReviews: []Review{
+				{Card: "Subject3"},
+				{Card: "Group.Subject1"},
+				{Card: "Group.SubGroup.Subject1"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Card Found",
+			src:  "Group.Subject1",
+			dst:  "Group.Subject2",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantReviews: []Review{
+				{Card: "Group.SubGroup.Subject2"},
+				{Card: "Group.Subject1"},
+				{Card: "Group.SubGroup.Subject1"},
+			},
+			wantErr: ErrCardFound,
+		},
+		{
+			name: "Card Not Found",
+			src:  "Group.NotFound",
+			dst:  "Group.Subject3",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantReviews: []Review{
+				{Card: "Group.SubGroup.Subject2"},
+				{Card: "Group.Subject1"},
+				{Card: "Group.SubGroup.Subject1"},
+			},
+			wantErr: ErrCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStubAndCards()
+			r.reviews = []Review{
+				{Card: "Group.SubGroup.Subject2"},
+				{Card: "Group.Subject1"},
+				{Card: "Group.SubGroup.Subject1"},
+			}
+			err := r.MoveCard(tt.src, tt.dst)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.wantReviews, r.reviews) {
+				t.Errorf("Incorrect reviews. Want %v, got %v", tt.wantReviews, r.reviews)
+			}
+			if !reflect.DeepEqual(tt.want, r.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, r.cards)
+			}
+		})
+	}
+}
+
+func TestMoveGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		dst         string
+		want        []Card
+		wantGroups  []Group
+		wantReviews []Review
+		wantErr     error
+	}{
+		{
+			name: "Normal",
+			src:  "Group.SubGroup",
+			dst:  "Other",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Other.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Other.Subject2", Desc: "Value2"},
+			},
+			wantGroups: []Group{
+				{Name: "Group", NewPerDay: 10},
+				{Name: "Other", NewPerDay: 5},
+			},
+			wantReviews: []Review{
+				{Card: "Other.Subject2"},
+				{Card: "Group.Subject1"},
+				{Card: "Other.Subject1"},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "Group Found",
+			src:  "Group.SubGroup",
+			dst:  "Group",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantGroups: []Group{
+				{Name: "Group", NewPerDay: 10},
+				{Name: "Group.SubGroup", NewPerDay: 5},
+			},
+			wantReviews: []Review{
+				{Card: "Group.SubGroup.Subject2"},
+				{Card: "Group.Subject1"},
+				{Card: "Group.SubGroup.Subject1"},
+			},
+			wantErr: ErrGroupFound,
+		},
+		{
+			name: "Group Not Found",
+			src:  "NotFound",
+			dst:  "Other",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantGroups: []Group{
+				{Name: "Group", NewPerDay: 10},
+				{Name: "Group.SubGroup", NewPerDay: 5},
+			},
+			wantReviews: []Review{
+				{Card: "Group.SubGroup.Subject2"},
+				{Card: "Group.Subject1"},
+				{Card: "Group.SubGroup.Subject1"},
+			},
+			wantErr: ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStubAndCards()
+			r.reviews = []Review{
+				{Card: "Group.SubGroup.Subject2"},
+				{Card: "Group.Subject1"},
+				{Card: "Group.SubGroup.Subject1"},
+			}
+			r.groups = []Group{
+				{Name: "Group", NewPerDay: 10},
+				{Name: "Group.SubGroup", NewPerDay: 5},
+			}
+			err := r.MoveGroup(tt.src, tt.dst)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.wantReviews, r.reviews) {
+				t.Errorf("Incorrect reviews. Want %v, got %v", tt.wantReviews, r.reviews)
+			}
+			if !reflect.DeepEqual(tt.want, r.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, r.cards)
+			}
+			if !reflect.DeepEqual(tt.wantGroups, r.groups) {
+				t.Errorf("Incorrect groups. Want %v, got %v", tt.wantGroups, r.groups)
+			}
+		})
+	}
+}
+
+func TestNotes(t *testing.T) {
+	vocab := adding.NoteType{
+		Name:   "Vocab",
+		Fields: []string{"Word", "Meaning"},
+		Templates: []adding.Template{
+			{Name: "Recognise", Front: "{{Word}}", Back: "{{Meaning}}"},
+			{Name: "Recall", Front: "{{Meaning}}", Back: "{{Word}}"},
+		},
+	}
+	r := newRepositoryWithClockStub()
+
+	if err := r.AddNoteType(vocab); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := r.AddNoteType(vocab); err != ErrNoteTypeFound {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrNoteTypeFound, err)
+	}
+	if got, err := r.GetNoteType("Vocab"); err != nil || !reflect.DeepEqual(vocab, got) {
+		t.Errorf("Incorrect note type. Want %v, got %v (%v)", vocab, got, err)
+	}
+	if _, err := r.GetNoteType("Grammar"); err != ErrNoteTypeNotFound {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrNoteTypeNotFound, err)
+	}
+
+	fields := map[string]string{"Word": "猫", "Meaning": "cat"}
+	if err := r.AddNote("Japanese", adding.Note{Title: "Cat", Type: "Vocab", Fields: fields, Tags: []string{"n5"}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := r.AddNote("Japanese", adding.Note{Title: "Cat", Type: "Vocab"}); err != ErrCardFound {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrCardFound, err)
+	}
+
+	want := []getting.Card{{
+		ID:    "1",
+		Title: "Japanese.Cat",
+		Tags:  []string{"n5"},
+		Note: &getting.Note{
+			Type:   "Vocab",
+			Fields: fields,
+			Templates: []getting.Template{
+				{Name: "Recognise", Front: "{{Word}}", Back: "{{Meaning}}"},
+				{Name: "Recall", Front: "{{Meaning}}", Back: "{{Word}}"},
+			},
+		},
+	}}
+	got, err := r.GetCards("Japanese")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Incorrect cards. Want %+v, got %+v", want, got)
+	}
+	if faces := got[0].Faces(); !reflect.DeepEqual([]string{"Recognise", "Recall"}, faces) {
+		t.Errorf("Incorrect faces. Want %v, got %v", []string{"Recognise", "Recall"}, faces)
+	}
+}
+
+func TestGetSchedule(t *testing.T) {
+	due := time.Date(2022, time.November, 7, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		group   string
+		card    reviewing.Card
+		want    reviewing.Card
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			card:  reviewing.Card{Title: "Subject1", Face: getting.Forward},
+			want: reviewing.Card{
+				Title: "Subject1", Face: getting.Forward, Due: due,
+				State: reviewing.State{Interval: 6, EaseFactor: 2.5, Repetitions: 2, Box: 2},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "New Face",
+			group:   "Group",
+			card:    reviewing.Card{Title: "Subject1", Face: getting.Reverse},
+			want:    reviewing.Card{Title: "Subject1", Face: getting.Reverse},
+			wantErr: nil,
+		},
+		{
+			name:    "New Card",
+			group:   "Group",
+			card:    reviewing.Card{Title: "Subject2", Face: getting.Forward},
+			want:    reviewing.Card{Title: "Subject2", Face: getting.Forward},
+			wantErr: nil,
+		},
+		{
+			name:    "Face Not Found",
+			group:   "Group",
+			card:    reviewing.Card{Title: "Subject2", Face: getting.Reverse},
+			want:    reviewing.Card{},
+			wantErr: ErrFaceNotFound,
+		},
+		{
+			name:    "Card Not Found",
+			group:   "Group",
+			card:    reviewing.Card{Title: "Subject3"},
+			want:    reviewing.Card{},
+			wantErr: ErrCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStubAndCards()
+			r.cards[2].Reversible = true
+			r.cards[2].Schedules = map[string]Schedule{
+				getting.Forward: {Due: due, Interval: 6, EaseFactor: 2.5, Repetitions: 2, Box: 2},
+			}
+			card, err := r.GetSchedule(tt.group, tt.card)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, card) {
+				t.Errorf("Incorrect card. Want %v, got %v", tt.want, card)
+			}
+		})
+	}
+}
+
+func TestUpdateSchedule(t *testing.T) {
+	due := time.Date(2022, time.November, 7, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		group   string
+		card    reviewing.Card
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			card: reviewing.Card{
+				Title: "Subject1", Face: getting.Reverse, Due: due,
+				State: reviewing.State{Interval: 1, Stability: 3.7, Difficulty: 5.2, Repetitions: 1},
+			},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{
+					Title: "Group.Subject1", Desc: "Value1",
+					Schedules: map[string]Schedule{
+						getting.Reverse: {Due: due, Interval: 1, Stability: 3.7, Difficulty: 5.2, Repetitions: 1},
+					},
+				},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Card Not Found",
+			group: "Group",
+			card:  reviewing.Card{Title: "Subject3", Due: due},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStubAndCards()
+			err := r.UpdateSchedule(tt.group, tt.card)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, r.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, r.cards)
+			}
+		})
+	}
+}
+
+func TestGetConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		want    configuring.Config
+		wantErr error
+	}{
+		{
+			name:    "Normal",
+			group:   "Group",
+			want:    configuring.Config{Scheduler: "fsrs", Retention: 0.85},
+			wantErr: nil,
+		},
+		{
+			name:    "Unset",
+			group:   "Group.SubGroup",
+			want:    configuring.Config{},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStub()
+			r.groups = []Group{{Name: "Group", Scheduler: "fsrs", Retention: 0.85}}
+			config, err := r.GetConfig(tt.group)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, config) {
+				t.Errorf("Incorrect config. Want %v, got %v", tt.want, config)
+			}
+		})
+	}
+}
+
+func TestSetConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		config  configuring.Config
+		want    []Group
+		wantErr error
+	}{
+		{
+			name:   "Normal",
+			group:  "Group.SubGroup",
+			config: configuring.Config{Scheduler: "sm2"},
+			want: []Group{
+				{Name: "Group", Scheduler: "fsrs", Retention: 0.85},
+				{Name: "Group.SubGroup", Scheduler: "sm2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Overwrite",
+			group:  "Group",
+			config: configuring.Config{Scheduler: "leitner", Boxes: []int{1, 3, 7}},
+			want: []Group{
+				{Name: "Group", Scheduler: "leitner", Boxes: []int{1, 3, 7}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Limits",
+			group:  "Group",
+			config: configuring.Config{Scheduler: "fsrs", NewPerDay: 20, ReviewsPerDay: 200},
+			want: []Group{
+				{Name: "Group", Scheduler: "fsrs", NewPerDay: 20, ReviewsPerDay: 200},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Leech",
+			group:  "Group",
+			config: configuring.Config{LeechThreshold: 4, LeechAction: "suspend"},
+			want: []Group{
+				{Name: "Group", LeechThreshold: 4, LeechAction: "suspend"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Target",
+			group:  "Group",
+			config: configuring.Config{Target: time.Date(2022, time.December, 1, 0, 0, 0, 0, time.UTC), TargetReviews: 2},
+			want: []Group{
+				{Name: "Group", Target: time.Date(2022, time.December, 1, 0, 0, 0, 0, time.UTC), TargetReviews: 2},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStub()
+			r.groups = []Group{{Name: "Group", Scheduler: "fsrs", Retention: 0.85}}
+			err := r.SetConfig(tt.group, tt.config)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, r.groups) {
+				t.Errorf("Incorrect groups. Want %v, got %v", tt.want, r.groups)
+			}
+		})
+	}
+}
+
+func TestGetReviewSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		want    reviewing.Settings
+		wantErr error
+	}{
+		{
+			name:    "Normal",
+			group:   "Group",
+			want:    reviewing.Settings{Scheduler: "fsrs", Retention: 0.85},
+			wantErr: nil,
+		},
+		{
+			name:    "Inherited",
+			group:   "Group.SubGroup",
+			want:    reviewing.Settings{Scheduler: "leitner", Retention: 0.85, LeechThreshold: 4},
+			wantErr: nil,
+		},
+		{
+			name:    "Unset",
+			group:   "Other",
+			want:    reviewing.Settings{},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStub()
+			r.groups = []Group{
+				{Name: "Group", Scheduler: "fsrs", Retention: 0.85},
+				{Name: "Group.SubGroup", Scheduler: "leitner", LeechThreshold: 4},
+			}
+			settings, err := r.GetReviewSettings(tt.group)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, settings) {
+				t.Errorf("Incorrect settings. Want %v, got %v", tt.want, settings)
+			}
+		})
+	}
+}
+
+func TestMarkLeech(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		card    reviewing.Card
+		suspend bool
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:    "Tag",
+			group:   "Group",
+			card:    reviewing.Card{Title: "Subject1"},
+			suspend: false,
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1", Leech: true},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Suspend",
+			group:   "Group",
+			card:    reviewing.Card{Title: "Subject2"},
+			suspend: true,
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2", Suspended: true, Leech: true},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Card Not Found",
+			group:   "Group",
+			card:    reviewing.Card{Title: "Subject3"},
+			suspend: false,
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStubAndCards()
+			err := r.MarkLeech(tt.group, tt.card, tt.suspend)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, r.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, r.cards)
+			}
+		})
+	}
+}
+
+func TestSetCardSuspended(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		card    suspending.Card
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			card:  suspending.Card{Title: "Subject1"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1", Suspended: true},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Sub Group",
+			group: "Group.SubGroup",
+			card:  suspending.Card{Title: "Subject2"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2", Suspended: true},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Card Not Found",
+			group: "Group",
+			card:  suspending.Card{Title: "Subject3"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStubAndCards()
+			err := r.SetCardSuspended(tt.group, tt.card, true)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, r.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, r.cards)
+			}
+		})
+	}
+}
+
+func TestCardTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		card    tagging.Card
+		add     []string
+		remove  []string
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:  "Add",
+			group: "Group",
+			card:  tagging.Card{Title: "Subject1"},
+			add:   []string{"old", "go", "go"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1", Tags: []string{"go", "old"}},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:   "Add And Remove",
+			group:  "",
+			card:   tagging.Card{ID: "6"},
+			add:    []string{"go", "old"},
+			remove: []string{"old", "missing"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2", Tags: []string{"go"}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Card Not Found",
+			group: "Group",
+			card:  tagging.Card{Title: "Subject3"},
+			add:   []string{"go"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStubAndCards()
+			err := r.AddCardTags(tt.group, tt.card, tt.add)
+			if err == nil && tt.remove != nil {
+				err = r.RemoveCardTags(tt.group, tt.card, tt.remove)
+			}
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, r.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, r.cards)
+			}
+		})
+	}
+}
+
+func TestMedia(t *testing.T) {
+	r := newRepositoryWithClockStubAndCards()
+	diagram := attaching.Media{Name: "diagram.png", File: "abc.png"}
+
+	if err := r.AddMedia("abc.png", []byte("png")); err != nil {
+		t.Fatalf("Incorrect error. Want %v, got %v", nil, err)
+	}
+	if err := r.AddMedia("def.png", []byte("other")); err != nil {
+		t.Fatalf("Incorrect error. Want %v, got %v", nil, err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := r.AttachMedia("Group", attaching.Card{Title: "Subject1"}, diagram); err != nil {
+			t.Fatalf("Incorrect error. Want %v, got %v", nil, err)
+		}
+	}
+	if err := r.AttachMedia("Group", attaching.Card{Title: "Subject3"}, diagram); err != ErrCardNotFound {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrCardNotFound, err)
+	}
+
+	cards, _ := r.GetCards("Group")
+	want := []getting.Media{{Name: "diagram.png", File: "abc.png"}}
+	if !reflect.DeepEqual(cards[0].Media, want) {
+		t.Errorf("Incorrect card media. Want %v, got %v", want, cards[0].Media)
+	}
+	if got := string(r.media["abc.png"]); got != "png" {
+		t.Errorf("Incorrect media data. Want %v, got %v", "png", got)
+	}
+
+	if err := r.DeleteMedia("def.png"); err != nil {
+		t.Errorf("Incorrect error. Want %v, got %v", nil, err)
+	}
+	if err := r.DeleteMedia("def.png"); err != ErrMediaNotFound {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrMediaNotFound, err)
+	}
+	if data, err := r.ReadMedia("abc.png"); err != nil || string(data) != "png" {
+		t.Errorf("Incorrect media data. Want %v, got %v (%v)", "png", string(data), err)
+	}
+	if _, err := r.ReadMedia("def.png"); err != ErrMediaNotFound {
+		t.Errorf("Incorrect error. Want %v, got %v", ErrMediaNotFound, err)
+	}
+	files, err := r.GetMedia()
+	if want := []string{"abc.png"}; err != nil || !reflect.DeepEqual(files, want) {
+		t.Errorf("Incorrect media. Want %v, got %v (%v)", want, files, err)
+	}
+}
+
+func TestRemoveUnusedMediaNoCards(t *testing.T) {
+	r := newRepositoryWithClockStubAndCards()
+	at := attaching.New(getting.New(r), r)
+	if _, err := at.Attach("Group", attaching.Card{Title: "Subject1"}, attaching.File{Name: "a.png", Data: []byte("png")}); err != nil {
+		t.Fatalf("Incorrect error. Want %v, got %v", nil, err)
+	}
+
+	cards, _ := r.GetAllCards("")
+	for _, c := range cards {
+		g, title := storage.SplitPath(c.Title)
+		if err := r.DeleteCard(g, deleting.Card{Title: title}); err != nil {
+			t.Fatalf("Incorrect error. Want %v, got %v", nil, err)
+		}
+	}
+
+	removed, err := at.RemoveUnusedMedia()
+	if err != nil {
+		t.Fatalf("Incorrect error. Want %v, got %v", nil, err)
+	}
+	if len(removed) != 1 {
+		t.Errorf("Incorrect removed media. Want one file, got %v", removed)
+	}
+	if files, _ := r.GetMedia(); len(files) != 0 {
+		t.Errorf("Incorrect media left. Want none, got %v", files)
+	}
+}
+
+func TestSetGroupSuspended(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1", Suspended: true},
+				{Title: "Group.Subject2", Desc: "Value2", Suspended: true},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1", Suspended: true},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2", Suspended: true},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Sub Group",
+			group: "Group.SubGroup",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1", Suspended: true},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2", Suspended: true},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Group Not Found",
+			group: "NotFound",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStubAndCards()
+			err := r.SetGroupSuspended(tt.group, true)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, r.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, r.cards)
+			}
+		})
+	}
+}
+
+func TestSetCardBuried(t *testing.T) {
+	until := time.Date(2022, time.November, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		group   string
+		card    burying.Card
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			card:  burying.Card{Title: "Subject1"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1", BuriedTill: until},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Card Not Found",
+			group: "Group",
+			card:  burying.Card{Title: "Subject3"},
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrCardNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStubAndCards()
+			err := r.SetCardBuried(tt.group, tt.card, until)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, r.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, r.cards)
+			}
+		})
+	}
+}
+
+func TestSetGroupBuried(t *testing.T) {
+	until := time.Date(2022, time.November, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		group   string
+		want    []Card
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group.SubGroup",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1", BuriedTill: until},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2", BuriedTill: until},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "Group Not Found",
+			group: "NotFound",
+			want: []Card{
+				{Title: "Subject1", Desc: "Value1"},
+				{Title: "Subject2", Desc: "Value2"},
+				{Title: "Group.Subject1", Desc: "Value1"},
+				{Title: "Group.Subject2", Desc: "Value2"},
+				{Title: "Group.SubGroup.Subject1", Desc: "Value1"},
+				{ID: "6", Title: "Group.SubGroup.Subject2", Desc: "Value2"},
+			},
+			wantErr: ErrGroupNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStubAndCards()
+			err := r.SetGroupBuried(tt.group, until)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, r.cards) {
+				t.Errorf("Incorrect cards. Want %v, got %v", tt.want, r.cards)
+			}
+		})
+	}
+}
+
+func TestAddReview(t *testing.T) {
+	day := time.Date(2022, time.November, 1, 12, 0, 0, 0, time.UTC)
+	reviews := []reviewing.Review{
+		{
+			Card: "Group.Subject1", Face: getting.Forward, Time: day, Grade: reviewing.Good,
+			Duration: time.Second, LastInterval: 1, Interval: 6, Scheduler: reviewing.SM2,
+		},
+		{
+			Card: "Group.Subject2", Face: getting.Reverse, Time: day.Add(time.Hour), Grade: reviewing.Again,
+			Duration: 2 * time.Second, LastInterval: 6, Interval: 1, Scheduler: reviewing.FSRS,
+		},
+	}
+	want := []Review{
+		{
+			Card: "Group.Subject1", Face: getting.Forward, Time: day, Grade: 2,
+			Duration: time.Second, LastInterval: 1, Interval: 6, Scheduler: reviewing.SM2,
+		},
+		{
+			Card: "Group.Subject2", Face: getting.Reverse, Time: day.Add(time.Hour), Grade: 0,
+			Duration: 2 * time.Second, LastInterval: 6, Interval: 1, Scheduler: reviewing.FSRS,
+		},
+	}
+
+	r := newRepositoryWithClockStub()
+	for _, rv := range reviews {
+		if err := r.AddReview(rv); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+
+	if !reflect.DeepEqual(want, r.reviews) {
+		t.Errorf("Incorrect reviews. Want %v, got %v", want, r.reviews)
+	}
+}
+
+func TestGetReviews(t *testing.T) {
+	day := time.Date(2022, time.November, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		reviews []Review
+		want    []logging.Review
+		wantErr error
+	}{
+		{
+			name: "Normal",
+			reviews: []Review{
+				{Card: "Group.Subject1", Time: day, Grade: 2, Interval: 1, Scheduler: reviewing.SM2},
+				{Card: "Group.Subject2", Time: day, Grade: 3, Interval: 4, Duration: time.Second},
+			},
+			want: []logging.Review{
+				{Card: "Group.Subject1", Time: day, Grade: reviewing.Good, Interval: 1, Scheduler: reviewing.SM2},
+				{Card: "Group.Subject2", Time: day, Grade: reviewing.Easy, Interval: 4, Duration: time.Second},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "Empty Log",
+			reviews: nil,
+			want:    []logging.Review{},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStub()
+			r.reviews = tt.reviews
+			reviews, err := r.GetReviews()
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, reviews) {
+				t.Errorf("Incorrect reviews. Want %v, got %v", tt.want, reviews)
+			}
+		})
+	}
+}
+
+func TestSetDailyCount(t *testing.T) {
+	day := time.Date(2022, time.November, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		group   string
+		count   studying.DailyCount
+		want    []Group
+		wantErr error
+	}{
+		{
+			name:  "Normal",
+			group: "Group",
+			count: studying.DailyCount{Day: day, New: 2, Reviews: 5},
+			want: []Group{
+				{Name: "Group", NewPerDay: 10, Studied: DailyCount{Day: day, New: 2, Reviews: 5}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "New Group",
+			group: "Group.SubGroup",
+			count: studying.DailyCount{Day: day, New: 1},
+			want: []Group{
+				{Name: "Group", NewPerDay: 10, Studied: DailyCount{Day: day.AddDate(0, 0, -1), New: 10}},
+				{Name: "Group.SubGroup", Studied: DailyCount{Day: day, New: 1}},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRepositoryWithClockStub()
+			r.groups = []Group{
+				{Name: "Group", NewPerDay: 10, Studied: DailyCount{Day: day.AddDate(0, 0, -1), New: 10}},
+			}
+			err := r.SetDailyCount(tt.group, tt.count)
+
+			if err != tt.wantErr {
+				t.Errorf("Incorrect error. Want %v, got %v", tt.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(tt.want, r.groups) {
+				t.Errorf("Incorrect groups. Want %v, got %v", tt.want, r.groups)
+			}
+
+			count, err := r.GetDailyCount(tt.group)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.count, count) {
+				t.Errorf("Incorrect count. Want %v, got %v", tt.count, count)
+			}
+		})
+	}
+}
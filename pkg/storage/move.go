@@ -0,0 +1,15 @@
+package storage
+
+import "strings"
+
+// MovedPath returns the path a card at p has after moving src to dst, and
+// whether the move changes it. A group move moves every card under src.
+func MovedPath(p, src, dst string, group bool) (string, bool) {
+	if group && strings.HasPrefix(p, src+".") {
+		return dst + strings.TrimPrefix(p, src), true
+	}
+	if !group && p == src {
+		return dst, true
+	}
+	return p, false
+}
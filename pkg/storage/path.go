@@ -0,0 +1,20 @@
+package storage
+
+import "strings"
+
+// JoinPath returns the dotted path of the item under the group.
+func JoinPath(g, item string) string {
+	if g == "" {
+		return item
+	}
+	return g + "." + item
+}
+
+// SplitPath splits a dotted path into its parent group and its last item.
+func SplitPath(p string) (string, string) {
+	i := strings.LastIndex(p, ".")
+	if i < 0 {
+		return "", p
+	}
+	return p[:i], p[i+1:]
+}
@@ -0,0 +1,21 @@
+package storage
+
+// Revised reports whether updating a card's description and answers changes
+// them, leaving the answers as they are if the new ones are nil.
+func Revised(desc string, answers []string, newDesc string, newAnswers []string) bool {
+	if desc != newDesc {
+		return true
+	}
+	if newAnswers == nil {
+		return false
+	}
+	if len(answers) != len(newAnswers) {
+		return true
+	}
+	for i := range answers {
+		if answers[i] != newAnswers[i] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package storage
+
+import "sort"
+
+// AddTags returns the tags with the new ones added, sorted and without
+// duplicates.
+func AddTags(tags, add []string) []string {
+	set := map[string]bool{}
+	for _, t := range tags {
+		set[t] = true
+	}
+	for _, t := range add {
+		set[t] = true
+	}
+	return sortedTags(set)
+}
+
+// RemoveTags returns the tags without the ones removed, sorted.
+func RemoveTags(tags, remove []string) []string {
+	set := map[string]bool{}
+	for _, t := range tags {
+		set[t] = true
+	}
+	for _, t := range remove {
+		delete(set, t)
+	}
+	return sortedTags(set)
+}
+
+func sortedTags(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	tags := make([]string, 0, len(set))
+	for t := range set {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+	return tags
+}